@@ -0,0 +1,180 @@
+// yd4b-server は yd4b.Client を社内向けの REST API として公開するプロキシサーバです。
+//
+// 登録済みの送信元IPアドレスと認証情報を持つホストでのみ起動し、API トークンを保持して
+// GET /searchcode/{code} と POST /addresszip を中継します。
+//
+// 環境変数:
+//
+//	YD4B_ORIGIN             APIのオリジン（必須）
+//	YD4B_CLIENT_ID          クライアントID（必須）
+//	YD4B_CLIENT_SECRET      クライアントシークレット（YD4B_CLIENT_SECRET_FILE と排他）
+//	YD4B_CLIENT_SECRET_FILE クライアントシークレットを格納したファイルのパス（YD4B_CLIENT_SECRET と排他）
+//	YD4B_MYIP               登録済みのグローバルIPアドレス（必須、"auto" で自動検出）
+//	YD4B_ECUID              プロバイダーのユーザーID
+//	YD4B_SERVER_ADDR        待ち受けアドレス（既定値: :8080）
+//	YD4B_SERVER_API_KEYS    呼び出し元名とAPIキーの組（例: "billing:key1,checkout:key2"）（必須）
+//	YD4B_SERVER_QUOTA       呼び出し元ごとの1分あたりの上限リクエスト数（0は無制限、既定値: 0）
+//	YD4B_SERVER_CACHE_TTL   レスポンスのキャッシュ期間（0で無効、既定値: 10m）
+//	YD4B_SERVER_TOKEN_FILE  複数のレプリカでトークンを共有するためのファイルのパス
+//
+// 401 と 429 は呼び出し元の API キーとクォータによる拒否にのみ使用します。
+// 上流の API から返された 401、403、5xx は 502、429 は 503 として、詳細を含めずに返します。
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+)
+
+// config はサーバの設定です。クライアントの設定は [yd4b.NewClientFromEnv] で読み込みます。
+type config struct {
+	addr         string
+	apiKeys      map[string]string // APIキー → 呼び出し元名
+	quota        int
+	cacheTTL     time.Duration
+	cacheEntries int
+	tokenFile    string
+}
+
+// loadConfig は環境変数から設定を読み込みます。
+func loadConfig(getenv func(string) string) (cfg config, err error) {
+	cfg = config{
+		addr:         getenv("YD4B_SERVER_ADDR"),
+		tokenFile:    getenv("YD4B_SERVER_TOKEN_FILE"),
+		apiKeys:      make(map[string]string),
+		cacheTTL:     10 * time.Minute,
+		cacheEntries: 10000,
+	}
+	if cfg.addr == "" {
+		cfg.addr = ":8080"
+	}
+
+	for _, pair := range strings.Split(getenv("YD4B_SERVER_API_KEYS"), ",") {
+		name, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || name == "" || key == "" {
+			continue
+		}
+		cfg.apiKeys[key] = name
+	}
+	if len(cfg.apiKeys) == 0 {
+		err = errors.New("YD4B_SERVER_API_KEYS is required")
+		return
+	}
+
+	if v := getenv("YD4B_SERVER_QUOTA"); v != "" {
+		if cfg.quota, err = strconv.Atoi(v); err != nil {
+			err = fmt.Errorf("invalid YD4B_SERVER_QUOTA: %w", err)
+			return
+		}
+	}
+	if v := getenv("YD4B_SERVER_CACHE_TTL"); v != "" {
+		if cfg.cacheTTL, err = time.ParseDuration(v); err != nil {
+			err = fmt.Errorf("invalid YD4B_SERVER_CACHE_TTL: %w", err)
+			return
+		}
+	}
+	return
+}
+
+// minRefreshInterval はトークンの更新を試みる最短の間隔です。
+// 失敗時や expires_in が返されなかった場合に /j/token を連続して呼び出さないようにします。
+const minRefreshInterval = 30 * time.Second
+
+// refreshToken はトークンを取得してクライアントに設定し、次回の更新までの待ち時間を返します。
+func refreshToken(ctx context.Context, client *yd4b.Client) (time.Duration, error) {
+	res, err := client.GetTokenContext(ctx)
+	if err != nil {
+		return minRefreshInterval, err
+	}
+	client.SetTokenResponse(res)
+	// 有効期限の8割が経過した時点で更新する
+	return max(time.Duration(res.ExpiresIn)*time.Second*8/10, minRefreshInterval), nil
+}
+
+// keepToken はトークンを定期的に更新し続けます。
+func keepToken(ctx context.Context, client *yd4b.Client, wait time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		var err error
+		if wait, err = refreshToken(ctx, client); err != nil {
+			log.Printf("token refresh failed: %v", err)
+		}
+	}
+}
+
+func run() error {
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	client, err := yd4b.NewClientFromEnv()
+	if err != nil {
+		return err
+	}
+	client.SetSingleflight(true)
+
+	// 登録済みの送信元IPアドレスと照合できるよう、送信されるIPアドレスを起動時に確認する
+	ip, err := client.DiagnoseIP(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("x-forwarded-for: %s (%s)", ip.Sent, ip.Source)
+	if cfg.tokenFile != "" {
+		client.SetTokenStore(yd4b.NewFileTokenStore(cfg.tokenFile))
+	}
+
+	wait, err := refreshToken(ctx, client)
+	if err != nil {
+		// 起動は継続し、readyz で未準備を通知する
+		log.Printf("initial token fetch failed: %v", err)
+	}
+	go keepToken(ctx, client, wait)
+
+	srv := &http.Server{
+		Addr:              cfg.addr,
+		Handler:           newServer(client, cfg).routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", cfg.addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Print("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+func main() {
+	if err := run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+)
+
+// addressZipRequest は POST /addresszip のリクエストボディです。
+// 未指定の項目はゼロ値となり、上流のリクエストでも省略されます。
+type addressZipRequest struct {
+	PrefCode   string `json:"pref_code"`
+	PrefName   string `json:"pref_name"`
+	PrefKana   string `json:"pref_kana"`
+	PrefRoma   string `json:"pref_roma"`
+	CityCode   string `json:"city_code"`
+	CityName   string `json:"city_name"`
+	CityKana   string `json:"city_kana"`
+	CityRoma   string `json:"city_roma"`
+	TownName   string `json:"town_name"`
+	TownKana   string `json:"town_kana"`
+	TownRoma   string `json:"town_roma"`
+	Freeword   string `json:"freeword"`
+	FlgGetCity int    `json:"flg_getcity"`
+	FlgGetPref int    `json:"flg_getpref"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+}
+
+// cacheEntry はキャッシュされたレスポンスです。
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// responseCache は TTL 付きのレスポンスキャッシュです。
+type responseCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cacheEntry
+}
+
+// get は有効期限内のキャッシュを返します。
+func (c *responseCache) get(key string, now time.Time) ([]byte, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || now.After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.body, true
+}
+
+// set はレスポンスをキャッシュします。上限に達した場合は期限切れのエントリを削除し、それでも足りなければ全削除します。
+func (c *responseCache) set(key string, body []byte, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.maxEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = cacheEntry{body: body, expires: now.Add(c.ttl)}
+}
+
+// quotaWindow は呼び出し元ごとの固定ウィンドウの利用状況です。
+type quotaWindow struct {
+	start time.Time
+	count int
+}
+
+// quotaLimiter は呼び出し元ごとに1分あたりのリクエスト数を制限します。
+type quotaLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	windows map[string]*quotaWindow
+}
+
+// allow はリクエストを許可するかどうかを返します。許可しない場合は次のウィンドウまでの時間も返します。
+func (q *quotaLimiter) allow(caller string, now time.Time) (bool, time.Duration) {
+	if q.limit <= 0 {
+		return true, 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	w, ok := q.windows[caller]
+	if !ok || now.Sub(w.start) >= time.Minute {
+		w = &quotaWindow{start: now}
+		q.windows[caller] = w
+	}
+	if w.count >= q.limit {
+		return false, w.start.Add(time.Minute).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// server は yd4b.Client を社内向け REST API として公開するプロキシです。
+type server struct {
+	client  *yd4b.Client
+	apiKeys map[string]string // APIキー → 呼び出し元名
+	cache   *responseCache
+	quota   *quotaLimiter
+	now     func() time.Time
+}
+
+// newServer は server を生成します。
+func newServer(client *yd4b.Client, cfg config) *server {
+	return &server{
+		client:  client,
+		apiKeys: cfg.apiKeys,
+		cache:   &responseCache{ttl: cfg.cacheTTL, maxEntries: cfg.cacheEntries, entries: make(map[string]cacheEntry)},
+		quota:   &quotaLimiter{limit: cfg.quota, windows: make(map[string]*quotaWindow)},
+		now:     time.Now,
+	}
+}
+
+// routes はルーティングを設定した http.Handler を返します。
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("GET /readyz", s.handleReadyz)
+	mux.Handle("GET /searchcode/{code}", s.authenticate(http.HandlerFunc(s.handleSearchcode)))
+	mux.Handle("POST /addresszip", s.authenticate(http.HandlerFunc(s.handleAddressZip)))
+	return mux
+}
+
+// authenticate は API キーによる認証と呼び出し元ごとのクォータ制限を行うミドルウェアです。
+// API キーは X-API-Key ヘッダ、または Authorization: Bearer ヘッダで指定します。
+func (s *server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-API-Key")
+		if key == "" {
+			key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+
+		caller := ""
+		for k, name := range s.apiKeys {
+			if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+				caller = name
+			}
+		}
+		if key == "" || caller == "" {
+			writeError(w, yd4b.NewError(http.StatusUnauthorized, "invalid api key"))
+			return
+		}
+
+		if ok, retry := s.quota.allow(caller, s.now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())+1))
+			writeError(w, yd4b.NewError(http.StatusTooManyRequests, "quota exceeded"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleHealthz はプロセスの生存確認に応答します。
+func (s *server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReadyz は有効な API トークンを保持しており、リクエストを処理できるかどうかに応答します。
+func (s *server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !s.client.TokenValid(s.now()) {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "no token"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+// handleSearchcode は GET /searchcode/{code} を処理します。
+// クエリパラメータ page, limit, choikitype, searchtype をそのまま転送します。
+func (s *server) handleSearchcode(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	q := r.URL.Query()
+
+	// 未指定のパラメータは 0 となり、上流のリクエストでは省略される
+	params := map[string]int{"page": 0, "limit": 0, "choikitype": 0, "searchtype": 0}
+	for name := range params {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, yd4b.NewError(http.StatusBadRequest, "invalid "+name))
+			return
+		}
+		params[name] = n
+	}
+
+	s.serveCached(w, "searchcode:"+code+"?"+q.Encode(), func() (any, error) {
+		return s.client.SearchcodeContext(r.Context(), code,
+			yd4b.WithSCPage(params["page"]),
+			yd4b.WithSCLimit(params["limit"]),
+			yd4b.WithChoikitype(params["choikitype"]),
+			yd4b.WithSearchtype(params["searchtype"]),
+		)
+	})
+}
+
+// handleAddressZip は POST /addresszip を処理します。
+func (s *server) handleAddressZip(w http.ResponseWriter, r *http.Request) {
+	var req addressZipRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		writeError(w, yd4b.NewError(http.StatusBadRequest, "invalid request body"))
+		return
+	}
+	key, _ := json.Marshal(req)
+
+	s.serveCached(w, "addresszip:"+string(key), func() (any, error) {
+		return s.client.AddressZipContext(r.Context(),
+			yd4b.WithPrefCode(req.PrefCode), yd4b.WithPrefName(req.PrefName), yd4b.WithPrefKana(req.PrefKana), yd4b.WithPrefRoma(req.PrefRoma),
+			yd4b.WithCityCode(req.CityCode), yd4b.WithCityName(req.CityName), yd4b.WithCityKana(req.CityKana), yd4b.WithCityRoma(req.CityRoma),
+			yd4b.WithTownName(req.TownName), yd4b.WithTownKana(req.TownKana), yd4b.WithTownRoma(req.TownRoma),
+			yd4b.WithFreeword(req.Freeword), yd4b.WithFlgGetCity(req.FlgGetCity), yd4b.WithFlgGetPref(req.FlgGetPref),
+			yd4b.WithAZPage(req.Page), yd4b.WithAZLimit(req.Limit),
+		)
+	})
+}
+
+// serveCached はキャッシュがあればそれを返し、なければ fetch の結果を返してキャッシュします。
+func (s *server) serveCached(w http.ResponseWriter, key string, fetch func() (any, error)) {
+	if body, ok := s.cache.get(key, s.now()); ok {
+		w.Header().Set("X-Cache", "HIT")
+		writeRaw(w, http.StatusOK, body)
+		return
+	}
+
+	res, err := fetch()
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		writeError(w, errors.Join(yd4b.NewError(http.StatusInternalServerError, "json encoding error"), err))
+		return
+	}
+	s.cache.set(key, body, s.now())
+	w.Header().Set("X-Cache", "MISS")
+	writeRaw(w, http.StatusOK, body)
+}
+
+// writeRaw は JSON のバイト列をそのまま書き出します。
+func writeRaw(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// writeJSON は値を JSON として書き出します。
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, _ := json.Marshal(v)
+	writeRaw(w, status, body)
+}
+
+// writeUpstreamError は上流の API から返されたエラーを JSON として書き出します。
+// 401、403 はこのサーバ自身の認証情報や送信元IPアドレスの問題であり、429 はこのサーバ全体への制限であるため、
+// 呼び出し元の API キーやクォータによるエラーと区別できるよう、5xx とともに詳細を含まない 502、503 に置き換えます。
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	var e *yd4b.Error
+	if errors.As(err, &e) {
+		switch {
+		case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden, e.StatusCode >= 500:
+			err = yd4b.NewError(http.StatusBadGateway, "upstream error")
+		case e.StatusCode == http.StatusTooManyRequests:
+			err = yd4b.NewError(http.StatusServiceUnavailable, "upstream rate limited")
+		}
+	}
+	writeError(w, err)
+}
+
+// writeError はエラーを JSON として書き出します。[yd4b.Error] 以外は 502 として扱います。
+func writeError(w http.ResponseWriter, err error) {
+	var e *yd4b.Error
+	if !errors.As(err, &e) {
+		e = yd4b.NewError(http.StatusBadGateway, "upstream error")
+	}
+	body, _ := e.ToJSON()
+	writeRaw(w, e.StatusCode, body)
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer は上流へのリクエストをスタブした server を生成します。
+func newTestServer(t *testing.T, cfg config, calls *int32) *server {
+	t.Helper()
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetToken("token")
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+		if strings.Contains(req.URL.Path, "/searchcode/0000000") {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"request_id":"x","error_code":"404-1-0001","message":"not found"}`)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"count":1,"addresses":[{"zip_code":"1000001"}]}`)),
+		}, nil
+	})
+	if cfg.apiKeys == nil {
+		cfg.apiKeys = map[string]string{"key1": "billing"}
+	}
+	if cfg.cacheEntries == 0 {
+		cfg.cacheEntries = 100
+	}
+	return newServer(client, cfg)
+}
+
+func TestServer_Auth(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{name: "no key", header: nil, want: http.StatusUnauthorized},
+		{name: "wrong key", header: map[string]string{"X-API-Key": "nope"}, want: http.StatusUnauthorized},
+		{name: "x-api-key", header: map[string]string{"X-API-Key": "key1"}, want: http.StatusOK},
+		{name: "bearer", header: map[string]string{"Authorization": "Bearer key1"}, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			h := newTestServer(t, config{}, &calls).routes()
+			req := httptest.NewRequest(http.MethodGet, "/searchcode/1000001", nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestServer_Quota(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	s := newTestServer(t, config{quota: 2}, &calls)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	h := s.routes()
+
+	do := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/searchcode/1000001", nil)
+		req.Header.Set("X-API-Key", "key1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, do().Code)
+	assert.Equal(t, http.StatusOK, do().Code)
+	rec := do()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "61", rec.Header().Get("Retry-After"))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, http.StatusOK, do().Code)
+}
+
+func TestServer_Searchcode(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		want     int
+		wantBody string
+	}{
+		{name: "ok", path: "/searchcode/1000001?limit=10", want: http.StatusOK, wantBody: `"zip_code":"1000001"`},
+		{name: "bad param", path: "/searchcode/1000001?limit=abc", want: http.StatusBadRequest, wantBody: "invalid limit"},
+		{name: "not found", path: "/searchcode/0000000", want: http.StatusNotFound, wantBody: `"status_code":404`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			h := newTestServer(t, config{}, &calls).routes()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-API-Key", "key1")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestServer_UpstreamErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		want     int
+		wantBody string
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, want: http.StatusBadGateway, wantBody: "upstream error"},
+		{name: "forbidden", status: http.StatusForbidden, want: http.StatusBadGateway, wantBody: "upstream error"},
+		{name: "server error", status: http.StatusInternalServerError, want: http.StatusBadGateway, wantBody: "upstream error"},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, want: http.StatusBadGateway, wantBody: "upstream error"},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: http.StatusServiceUnavailable, wantBody: "upstream rate limited"},
+		{name: "bad request", status: http.StatusBadRequest, want: http.StatusBadRequest, wantBody: `"status_code":400`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			s := newTestServer(t, config{}, &calls)
+			s.client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: tt.status,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"message":"ip address not registered"}`)),
+				}, nil
+			})
+			req := httptest.NewRequest(http.MethodGet, "/searchcode/1000001", nil)
+			req.Header.Set("X-API-Key", "key1")
+			rec := httptest.NewRecorder()
+			s.routes().ServeHTTP(rec, req)
+
+			// 上流の認証エラーは呼び出し元の API キーの拒否と区別される
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+			assert.NotContains(t, rec.Body.String(), "ip address not registered")
+		})
+	}
+}
+
+func TestServer_AddressZip(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "ok", body: `{"pref_code":"13","town_name":"千代田"}`, want: http.StatusOK},
+		{name: "invalid body", body: `{`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			h := newTestServer(t, config{}, &calls).routes()
+			req := httptest.NewRequest(http.MethodPost, "/addresszip", strings.NewReader(tt.body))
+			req.Header.Set("X-API-Key", "key1")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestServer_Cache(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wantCalls int32
+		wantCache []string
+	}{
+		{name: "enabled", ttl: time.Minute, wantCalls: 1, wantCache: []string{"MISS", "HIT"}},
+		{name: "disabled", ttl: 0, wantCalls: 2, wantCache: []string{"MISS", "MISS"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			h := newTestServer(t, config{cacheTTL: tt.ttl}, &calls).routes()
+			for _, want := range tt.wantCache {
+				req := httptest.NewRequest(http.MethodGet, "/searchcode/1000001", nil)
+				req.Header.Set("X-API-Key", "key1")
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, want, rec.Header().Get("X-Cache"))
+			}
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestServer_Health(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	s := newTestServer(t, config{}, &calls)
+	h := s.routes()
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+
+	s.client.SetToken("")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// 期限切れのトークンは未準備とみなす
+	s.client.SetTokenResponse(yd4b.TokenResponse{ExpiresIn: 600, Token: "token"})
+	s.now = func() time.Time { return time.Now().Add(time.Hour) }
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestRefreshToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		want      time.Duration
+		wantErr   bool
+		wantToken bool
+	}{
+		{name: "expires in", status: http.StatusOK, body: `{"expires_in":600,"token":"t"}`, want: 480 * time.Second, wantToken: true},
+		{name: "expires in omitted", status: http.StatusOK, body: `{"token":"t"}`, want: 30 * time.Second, wantToken: true},
+		{name: "expires in zero", status: http.StatusOK, body: `{"expires_in":0,"token":"t"}`, want: 30 * time.Second, wantToken: true},
+		{name: "short expires in", status: http.StatusOK, body: `{"expires_in":10,"token":"t"}`, want: 30 * time.Second, wantToken: true},
+		{name: "error", status: http.StatusInternalServerError, body: `{}`, want: 30 * time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: tt.status, Body: io.NopCloser(bytes.NewBufferString(tt.body))}, nil
+			})
+
+			wait, err := refreshToken(context.Background(), client)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, wait)
+			assert.Equal(t, tt.wantToken, client.HasToken())
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	base := map[string]string{
+		"YD4B_SERVER_API_KEYS": "billing:key1, checkout:key2",
+	}
+	tests := []struct {
+		name     string
+		override map[string]string
+		wantErr  bool
+		check    func(t *testing.T, cfg config)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, ":8080", cfg.addr)
+				assert.Equal(t, 10*time.Minute, cfg.cacheTTL)
+				assert.Equal(t, map[string]string{"key1": "billing", "key2": "checkout"}, cfg.apiKeys)
+			},
+		},
+		{
+			name:     "overrides",
+			override: map[string]string{"YD4B_SERVER_ADDR": ":9000", "YD4B_SERVER_QUOTA": "60", "YD4B_SERVER_CACHE_TTL": "0"},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, ":9000", cfg.addr)
+				assert.Equal(t, 60, cfg.quota)
+				assert.Equal(t, time.Duration(0), cfg.cacheTTL)
+			},
+		},
+		{name: "no api keys", override: map[string]string{"YD4B_SERVER_API_KEYS": ""}, wantErr: true},
+		{name: "invalid quota", override: map[string]string{"YD4B_SERVER_QUOTA": "x"}, wantErr: true},
+		{name: "invalid ttl", override: map[string]string{"YD4B_SERVER_CACHE_TTL": "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := loadConfig(func(key string) string {
+				if v, ok := tt.override[key]; ok {
+					return v
+				}
+				return base[key]
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			tt.check(t, cfg)
+		})
+	}
+}
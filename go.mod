@@ -2,7 +2,10 @@ module github.com/aethiopicuschan/yd4b-go
 
 go 1.24.2
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.33.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
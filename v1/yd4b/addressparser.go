@@ -0,0 +1,327 @@
+package yd4b
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b/normalize"
+)
+
+// prefectures は都道府県名の一覧です。
+var prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県",
+	"静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県",
+	"奈良県", "和歌山県", "鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県", "福岡県", "佐賀県", "長崎県",
+	"熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+// cityExceptions は市区町村の区切り文字を名称の途中に含み、規則では分割できない市区町村名です。
+var cityExceptions = []string{"東村山市", "武蔵村山市"}
+
+// ParsedAddress は住所文字列を分割した結果を表す構造体です。
+type ParsedAddress struct {
+	PrefName  string `json:"pref_name"` // 都道府県名
+	CityName  string `json:"city_name"` // 市区町村名（郡や政令指定都市の区を含む）
+	TownName  string `json:"town_name"` // 町域名
+	Remainder string `json:"remainder"` // 丁目・番地・建物名など（丁目・番地の数字は半角に正規化済み）
+}
+
+// ParseAddress は住所文字列を都道府県・市区町村・町域・それ以降に分割します。
+// 入力は NFKC 正規化され、全角数字や漢数字で書かれた丁目・番地は半角数字に変換されます。
+// 建物名などの丁目・番地以降の部分は変換せず、区切りの空白も残します。
+// 分割は規則に基づく推定であり、結果の正しさは [Client.ResolveAddress] で確認できます。
+func ParseAddress(s string) ParsedAddress {
+	var p ParsedAddress
+	spaced := strings.Join(strings.Fields(normalize.Hyphens(normalize.NFKC(s))), " ")
+	rest := strings.ReplaceAll(spaced, " ", "")
+
+	for _, pref := range prefectures {
+		if strings.HasPrefix(rest, pref) {
+			p.PrefName = pref
+			rest = strings.TrimPrefix(rest, pref)
+			break
+		}
+	}
+
+	p.CityName, rest = splitCity(rest)
+	rest = strings.TrimPrefix(strings.TrimPrefix(rest, "大字"), "字")
+	p.TownName, p.Remainder = splitTown(rest)
+	block, building := splitBlock(restoreSpaces(spaced, p.Remainder))
+	p.Remainder = normalize.BlockNumerals(block) + building
+	return p
+}
+
+// restoreSpaces は空白を除去した文字列の末尾 rest に対応する部分を、空白を含む元の文字列 spaced から取り出します。
+func restoreSpaces(spaced, rest string) string {
+	n := len([]rune(rest))
+	rs := []rune(spaced)
+	i := len(rs)
+	for ; i > 0 && n > 0; i-- {
+		if rs[i-1] != ' ' {
+			n--
+		}
+	}
+	return strings.TrimSpace(string(rs[i:]))
+}
+
+// splitBlock は町域以降の文字列を丁目・番地の部分と、それ以降の建物名などの部分に分割します。
+// 建物名の部分は先頭の区切り文字を含めてそのまま返します。
+func splitBlock(s string) (block string, building string) {
+	rs := []rune(s)
+	i := 0
+scan:
+	for i < len(rs) {
+		switch r := rs[i]; {
+		case unicode.IsDigit(r), r == '-':
+			i++
+		case r == ' ':
+			// 「1丁目 9番」のように番地の途中にある空白は番地に含める
+			if i > 0 && !unicode.IsDigit(rs[i-1]) && startsBlock(rs[i+1:]) {
+				i++
+				continue
+			}
+			break scan
+		case normalize.IsKanjiNumeral(r):
+			j := i
+			for j < len(rs) && normalize.IsKanjiNumeral(rs[j]) {
+				j++
+			}
+			// 「三井ビル」のように番地表記が続かない漢数字は建物名とみなす
+			if j < len(rs) && !hasBlockMarker(string(rs[j:])) {
+				break scan
+			}
+			i = j
+		default:
+			m := blockMarker(string(rs[i:]))
+			if m == "" {
+				break scan
+			}
+			i += len([]rune(m))
+		}
+	}
+	return string(rs[:i]), string(rs[i:])
+}
+
+// startsBlock は文字列が数字、または番地表記が続く漢数字で始まるかどうかを判定します。
+func startsBlock(rs []rune) bool {
+	if len(rs) == 0 {
+		return false
+	}
+	if unicode.IsDigit(rs[0]) {
+		return true
+	}
+	j := 0
+	for j < len(rs) && normalize.IsKanjiNumeral(rs[j]) {
+		j++
+	}
+	return j > 0 && hasBlockMarker(string(rs[j:]))
+}
+
+// blockMarker は文字列の先頭にある丁目・番地表記を返します。
+// 「の」は直後に数字が続く場合のみ番地表記とみなします。
+func blockMarker(s string) string {
+	for _, m := range blockMarkers {
+		if m != "-" && strings.HasPrefix(s, m) {
+			return m
+		}
+	}
+	if rest, ok := strings.CutPrefix(s, "の"); ok {
+		if r := []rune(rest); len(r) > 0 && (unicode.IsDigit(r[0]) || normalize.IsKanjiNumeral(r[0])) {
+			return "の"
+		}
+	}
+	return ""
+}
+
+// hasBlockMarker は文字列が丁目・番地表記またはハイフンで始まるかどうかを判定します。
+func hasBlockMarker(s string) bool {
+	return strings.HasPrefix(s, "-") || blockMarker(s) != ""
+}
+
+// isCitySuffix は市区町村名の末尾になりうる文字かどうかを判定します。
+func isCitySuffix(r rune) bool {
+	return r == '市' || r == '区' || r == '町' || r == '村'
+}
+
+// splitCity は都道府県以降の文字列から市区町村名を切り出します。
+func splitCity(s string) (city string, rest string) {
+	for _, e := range cityExceptions {
+		if strings.HasPrefix(s, e) {
+			return e, strings.TrimPrefix(s, e)
+		}
+	}
+
+	rs := []rune(s)
+	start := 0
+	// 郡の場合は「○○郡○○町」までを市区町村名とする
+	for i := 0; i < len(rs) && i < 6; i++ {
+		if rs[i] == '郡' {
+			start = i + 1
+			break
+		}
+		if rs[i] == '市' && i > 0 {
+			break
+		}
+	}
+
+	for i := start + 1; i < len(rs); i++ {
+		if !isCitySuffix(rs[i]) {
+			continue
+		}
+		// 「四日市市」「大町市」のように区切り文字が続く場合は後ろを採用する
+		if i+1 < len(rs) && isCitySuffix(rs[i+1]) {
+			continue
+		}
+		end := i + 1
+		// 政令指定都市の区は市区町村名に含める
+		if rs[i] == '市' {
+			for j := end + 1; j < len(rs) && j <= end+4; j++ {
+				if unicode.IsDigit(rs[j]) {
+					break
+				}
+				if rs[j] == '区' {
+					end = j + 1
+					break
+				}
+			}
+		}
+		return string(rs[:end]), string(rs[end:])
+	}
+	return "", s
+}
+
+// blockMarkers は漢数字の直後に続く場合に番地等の開始とみなす文字列です。
+var blockMarkers = []string{"丁目", "丁", "番地", "番", "号", "-"}
+
+// splitTown は市区町村以降の文字列から町域名を切り出します。
+// 数字、または丁目・番地が続く漢数字が現れた位置までを町域名とします。
+func splitTown(s string) (town string, rest string) {
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		if unicode.IsDigit(rs[i]) {
+			return string(rs[:i]), string(rs[i:])
+		}
+		if !normalize.IsKanjiNumeral(rs[i]) {
+			continue
+		}
+		j := i
+		for j < len(rs) && normalize.IsKanjiNumeral(rs[j]) {
+			j++
+		}
+		after := string(rs[j:])
+		markers := blockMarkers
+		if i == 0 {
+			// 「一番町」のような町域名と区別するため、先頭では明確な表記のみを番地とみなす
+			markers = []string{"丁目", "番地", "-"}
+		}
+		for _, m := range markers {
+			if strings.HasPrefix(after, m) {
+				return string(rs[:i]), string(rs[i:])
+			}
+		}
+		i = j - 1
+	}
+	return s, ""
+}
+
+// AddressMatch は住所文字列から郵便番号を解決した結果を表す構造体です。
+type AddressMatch struct {
+	Parsed     ParsedAddress `json:"parsed"`     // 住所文字列の分割結果
+	Item       AddressItem   `json:"item"`       // 最も一致した住所アイテム
+	Confidence float64       `json:"confidence"` // 一致度（0〜1）
+}
+
+// scoreAddressItem は分割結果と住所アイテムの一致度を計算します。
+func scoreAddressItem(p ParsedAddress, item AddressItem) float64 {
+	component := func(want, got string) float64 {
+		switch {
+		case want == "":
+			return 0.5
+		case want == got:
+			return 1
+		case normalize.Equal(want, got):
+			return 0.9
+		case got != "" && (strings.HasPrefix(normalize.Key(got), normalize.Key(want)) || strings.HasPrefix(normalize.Key(want), normalize.Key(got))):
+			return 0.7
+		default:
+			return 0
+		}
+	}
+	return 0.2*component(p.PrefName, item.PrefName) +
+		0.3*component(p.CityName, item.CityName) +
+		0.5*component(p.TownName, item.TownName)
+}
+
+// ResolveAddress は住所文字列を分割し、構造化した条件で addresszip を検索して最も一致する住所を返します。
+// 町域まで一致しない場合は条件を緩めて再検索し、それでも見つからない場合はフリーワード検索を行います。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - address: 「東京都千代田区千代田1-1」のような住所文字列
+//
+// 戻り値:
+//   - AddressMatch: 最も一致した住所と一致度
+//   - error: 通信エラーや該当なしなど
+func (c *Client) ResolveAddress(ctx context.Context, address string) (match AddressMatch, err error) {
+	p := ParseAddress(address)
+	match.Parsed = p
+
+	var attempts [][]addressRequestOption
+	if p.PrefName != "" || p.CityName != "" {
+		structured := func(withTown bool) []addressRequestOption {
+			var opts []addressRequestOption
+			if p.PrefName != "" {
+				opts = append(opts, WithPrefName(p.PrefName))
+			}
+			if p.CityName != "" {
+				opts = append(opts, WithCityName(p.CityName))
+			}
+			if withTown && p.TownName != "" {
+				opts = append(opts, WithTownName(p.TownName))
+			}
+			return opts
+		}
+		if p.TownName != "" {
+			attempts = append(attempts, structured(true))
+		}
+		attempts = append(attempts, structured(false))
+	}
+	attempts = append(attempts, []addressRequestOption{WithFreeword(p.PrefName + p.CityName + p.TownName)})
+
+	for i, opts := range attempts {
+		res, e := c.AddressZipContext(ctx, opts...)
+		if e != nil {
+			var ye *Error
+			if errors.As(e, &ye) && ye.StatusCode == http.StatusNotFound {
+				continue
+			}
+			err = e
+			return
+		}
+		if len(res.Addresses) == 0 {
+			continue
+		}
+
+		// より詳細な条件で見つかった結果を優先し、その中で最も一致するものを採用する
+		for j, item := range res.Addresses {
+			score := scoreAddressItem(p, item)
+			if i == len(attempts)-1 {
+				// フリーワード検索の結果は信頼度を下げる
+				score *= 0.8
+			}
+			if j == 0 || score > match.Confidence {
+				match.Item, match.Confidence = item, score
+			}
+		}
+		return
+	}
+
+	err = NewError(http.StatusNotFound, "address not found")
+	return
+}
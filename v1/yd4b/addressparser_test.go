@@ -0,0 +1,170 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		input string
+		want  yd4b.ParsedAddress
+	}{
+		{
+			input: "東京都千代田区千代田1-1",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "千代田区", TownName: "千代田", Remainder: "1-1"},
+		},
+		{
+			input: "東京都　千代田区　丸の内一丁目９番１号　東京駅ビル",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "千代田区", TownName: "丸の内", Remainder: "1丁目9番1号 東京駅ビル"},
+		},
+		{
+			input: "東京都千代田区一番町10",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "千代田区", TownName: "一番町", Remainder: "10"},
+		},
+		{
+			input: "北海道札幌市中央区北一条西二丁目",
+			want:  yd4b.ParsedAddress{PrefName: "北海道", CityName: "札幌市中央区", TownName: "北一条西", Remainder: "2丁目"},
+		},
+		{
+			input: "三重県四日市市諏訪町１－５",
+			want:  yd4b.ParsedAddress{PrefName: "三重県", CityName: "四日市市", TownName: "諏訪町", Remainder: "1-5"},
+		},
+		{
+			input: "長野県大町市大町三二〇五番地",
+			want:  yd4b.ParsedAddress{PrefName: "長野県", CityName: "大町市", TownName: "大町", Remainder: "3205番地"},
+		},
+		{
+			input: "北海道虻田郡倶知安町北1条東",
+			want:  yd4b.ParsedAddress{PrefName: "北海道", CityName: "虻田郡倶知安町", TownName: "北", Remainder: "1条東"},
+		},
+		{
+			input: "東京都東村山市本町一丁目二番地の三",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "東村山市", TownName: "本町", Remainder: "1丁目2番地の3"},
+		},
+		{
+			input: "千代田区大手町二十三番",
+			want:  yd4b.ParsedAddress{CityName: "千代田区", TownName: "大手町", Remainder: "23番"},
+		},
+		{
+			input: "東京都千代田区千代田1-1 千代田ビル三階",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "千代田区", TownName: "千代田", Remainder: "1-1 千代田ビル三階"},
+		},
+		{
+			input: "東京都港区六本木6-10-1 六本木ヒルズ森タワー",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "港区", TownName: "六本木", Remainder: "6-10-1 六本木ヒルズ森タワー"},
+		},
+		{
+			input: "東京都新宿区西新宿二丁目一番一号三井ビル",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "新宿区", TownName: "西新宿", Remainder: "2丁目1番1号三井ビル"},
+		},
+		{
+			input: "東京都新宿区西新宿二丁目1-1三井ビル",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "新宿区", TownName: "西新宿", Remainder: "2丁目1-1三井ビル"},
+		},
+		{
+			input: "東京都千代田区丸の内一丁目 九番一号　千代田ビル",
+			want:  yd4b.ParsedAddress{PrefName: "東京都", CityName: "千代田区", TownName: "丸の内", Remainder: "1丁目 9番1号 千代田ビル"},
+		},
+		{
+			input: "京都府京都市左京区大字岩倉",
+			want:  yd4b.ParsedAddress{PrefName: "京都府", CityName: "京都市左京区", TownName: "岩倉"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, yd4b.ParseAddress(tt.input))
+		})
+	}
+}
+
+func TestClient_ResolveAddress(t *testing.T) {
+	chiyoda := `{"zip_code":"1000001","pref_code":"13","pref_name":"東京都","city_code":"13101","city_name":"千代田区","town_name":"千代田"}`
+	otemachi := `{"zip_code":"1000004","pref_code":"13","pref_name":"東京都","city_code":"13101","city_name":"千代田区","town_name":"大手町"}`
+
+	tests := []struct {
+		name           string
+		input          string
+		responses      []string
+		wantZip        string
+		wantConfidence float64
+		wantRequests   int
+		wantErrSubstr  string
+	}{
+		{
+			name:           "exact structured match",
+			input:          "東京都千代田区千代田1-1",
+			responses:      []string{`{"level":3,"count":1,"addresses":[` + chiyoda + `]}`},
+			wantZip:        "1000001",
+			wantConfidence: 1,
+			wantRequests:   1,
+		},
+		{
+			name:  "relaxed to city level",
+			input: "東京都千代田区大手町1-1",
+			responses: []string{
+				`{"level":2,"count":0,"addresses":[]}`,
+				`{"level":2,"count":2,"addresses":[` + chiyoda + `,` + otemachi + `]}`,
+			},
+			wantZip:        "1000004",
+			wantConfidence: 1,
+			wantRequests:   2,
+		},
+		{
+			name:  "freeword fallback",
+			input: "丸の内",
+			responses: []string{
+				`{"level":3,"count":1,"addresses":[{"zip_code":"1000005","pref_name":"東京都","city_name":"千代田区","town_name":"丸の内"}]}`,
+			},
+			wantZip:        "1000005",
+			wantConfidence: 0.8 * (0.2*0.5 + 0.3*0.5 + 0.5),
+			wantRequests:   1,
+		},
+		{
+			name:  "not found",
+			input: "東京都千代田区存在しない町",
+			responses: []string{
+				`{"level":2,"count":0,"addresses":[]}`,
+				`{"level":1,"count":0,"addresses":[]}`,
+				`{"level":0,"count":0,"addresses":[]}`,
+			},
+			wantErrSubstr: "address not found",
+			wantRequests:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []yd4b.AddressRequest
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				var b yd4b.AddressRequest
+				_ = json.NewDecoder(req.Body).Decode(&b)
+				requests = append(requests, b)
+				body := tt.responses[len(requests)-1]
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+			})
+
+			match, err := client.ResolveAddress(context.Background(), tt.input)
+			assert.Len(t, requests, tt.wantRequests)
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantZip, match.Item.ZipCode)
+			assert.InDelta(t, tt.wantConfidence, match.Confidence, 0.0001)
+		})
+	}
+}
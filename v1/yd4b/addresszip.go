@@ -1,34 +1,12 @@
 package yd4b
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
-	"net/url"
+	"reflect"
 )
 
-// addressRequest は住所情報をもとに郵便番号を検索するための内部リクエスト構造体です。
-// 各フィールドは API リクエストの JSON ボディにマッピングされます。
-type addressRequest struct {
-	PrefCode   string `json:"pref_code,omitempty"`   // 都道府県コード
-	PrefName   string `json:"pref_name,omitempty"`   // 都道府県名
-	PrefKana   string `json:"pref_kana,omitempty"`   // 都道府県名（カナ）
-	PrefRoma   string `json:"pref_roma,omitempty"`   // 都道府県名（ローマ字）
-	CityCode   string `json:"city_code,omitempty"`   // 市区町村コード
-	CityName   string `json:"city_name,omitempty"`   // 市区町村名
-	CityKana   string `json:"city_kana,omitempty"`   // 市区町村名（カナ）
-	CityRoma   string `json:"city_roma,omitempty"`   // 市区町村名（ローマ字）
-	TownName   string `json:"town_name,omitempty"`   // 町域名
-	TownKana   string `json:"town_kana,omitempty"`   // 町域名（カナ）
-	TownRoma   string `json:"town_roma,omitempty"`   // 町域名（ローマ字）
-	Freeword   string `json:"freeword,omitempty"`    // フリーワード検索
-	FlgGetCity int    `json:"flg_getcity,omitempty"` // 市区町村一覧取得フラグ（1: 有効）
-	FlgGetPref int    `json:"flg_getpref,omitempty"` // 都道府県一覧取得フラグ（1: 有効）
-	Page       int    `json:"page,omitempty"`        // ページ番号
-	Limit      int    `json:"limit,omitempty"`       // 取得件数の上限
-}
-
 // addressRequestOption は addressRequest にオプションを適用するためのインターフェースです。
 type addressRequestOption interface {
 	apply(*addressRequest)
@@ -99,6 +77,8 @@ func WithCityRoma(roma string) addressRequestOption {
 }
 
 // WithTownName は町域名を指定するオプションです。
+// 値はそのまま送信されるため、API から取得した町域名を表記を変えずに指定できます。
+// 利用者が入力した文字列を指定する場合は、必要に応じて normalize パッケージの Input で正規化してください。
 func WithTownName(name string) addressRequestOption {
 	return addressRequestOptionFunc(func(r *addressRequest) {
 		r.TownName = name
@@ -120,6 +100,7 @@ func WithTownRoma(roma string) addressRequestOption {
 }
 
 // WithFreeword はフリーワード検索語を指定するオプションです。
+// 値はそのまま送信されます。利用者が入力した文字列を指定する場合は、必要に応じて normalize パッケージの Input で正規化してください。
 func WithFreeword(word string) addressRequestOption {
 	return addressRequestOptionFunc(func(r *addressRequest) {
 		r.Freeword = word
@@ -154,29 +135,14 @@ func WithAZLimit(limit int) addressRequestOption {
 	})
 }
 
-// AddressResponse は住所から検索した郵便番号結果を表す構造体です。
-type AddressResponse struct {
-	Level     int           `json:"level"`     // 検索レベル
-	Page      int           `json:"page"`      // 現在のページ番号
-	Limit     int           `json:"limit"`     // １ページあたりの件数
-	Count     int           `json:"count"`     // 総件数
-	Addresses []AddressItem `json:"addresses"` // 検索結果の住所データ一覧
-}
-
-// AddressItem は住所検索結果の各アイテム（郵便番号含む）を表す構造体です。
-type AddressItem struct {
-	ZipCode  string `json:"zip_code"`  // 郵便番号
-	PrefCode string `json:"pref_code"` // 都道府県コード
-	PrefName string `json:"pref_name"` // 都道府県名
-	PrefKana string `json:"pref_kana"` // 都道府県名（カナ）
-	PrefRoma string `json:"pref_roma"` // 都道府県名（ローマ字）
-	CityCode string `json:"city_code"` // 市区町村コード
-	CityName string `json:"city_name"` // 市区町村名
-	CityKana string `json:"city_kana"` // 市区町村名（カナ）
-	CityRoma string `json:"city_roma"` // 市区町村名（ローマ字）
-	TownName string `json:"town_name"` // 町域名
-	TownKana string `json:"town_kana"` // 町域名（カナ）
-	TownRoma string `json:"town_roma"` // 町域名（ローマ字）
+// UnmarshalJSON は JSON をデコードし、構造体に定義されていないフィールドを Extras に格納します。
+func (a *AddressItem) UnmarshalJSON(data []byte) (err error) {
+	type plain AddressItem
+	if err = json.Unmarshal(data, (*plain)(a)); err != nil {
+		return
+	}
+	a.Extras, err = extraFields(data, reflect.TypeFor[plain]())
+	return
 }
 
 // newAddressRequest はオプションを適用して addressRequest を生成します。
@@ -196,60 +162,30 @@ func newAddressRequest(opts ...addressRequestOption) addressRequest {
 //   - AddressResponse: 住所から取得した郵便番号検索結果
 //   - error: 通信エラー、ステータスコード異常、デコード失敗など
 func (c *Client) AddressZip(opts ...addressRequestOption) (res AddressResponse, err error) {
-	// リクエストボディ用構造体を生成
-	reqBody := newAddressRequest(opts...)
-
-	// エンドポイント組み立て
-	endpoint, err := url.JoinPath(c.origin, "api", c.version, "addresszip")
-	if err != nil {
-		err = errors.Join(NewError(500, "endpoint error"), err)
-		return
-	}
-	u, err := url.Parse(endpoint)
-	if err != nil {
-		err = errors.Join(NewError(500, "url parse error"), err)
-		return
-	}
-	if c.ecuid != "" {
-		q := u.Query()
-		q.Set("ec_uid", c.ecuid)
-		u.RawQuery = q.Encode()
-	}
-
-	// JSON エンコーディング
-	jsonBody, err := json.Marshal(reqBody)
-	if err != nil {
-		err = errors.Join(NewError(500, "json encoding error"), err)
-		return
-	}
-
-	// HTTP リクエスト生成
-	req, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
-	if err != nil {
-		err = errors.Join(NewError(500, "request creation error"), err)
-		return
-	}
-
-	// リクエスト送信
-	resp, err := c.do(req)
-	if err != nil {
-		err = errors.Join(NewError(500, "client do error"), err)
-		return
-	}
-	defer resp.Body.Close()
+	return c.AddressZipContext(context.Background(), opts...)
+}
 
-	// ステータスコード確認
-	if resp.StatusCode != http.StatusOK {
-		err = NewError(resp.StatusCode, "unexpected status code")
-		return
-	}
+// addressZipEndpoint は住所検索APIの記述子です。
+var addressZipEndpoint = endpoint[addressRequest]{
+	name:   "addresszip",
+	method: http.MethodPost,
+	path:   func(addressRequest) []string { return []string{"addresszip"} },
+	body:   true,
+	ecuid:  true,
+}
 
-	// レスポンス JSON デコード
-	err = json.NewDecoder(resp.Body).Decode(&res)
-	if err != nil {
-		err = errors.Join(NewError(500, "json decoding error"), err)
-		return
-	}
+// AddressZipContext はコンテキストを指定して [Client.AddressZip] を実行します。
+// コンテキストがキャンセルされた場合、実行中のリクエストも中断されます。
+func (c *Client) AddressZipContext(ctx context.Context, opts ...addressRequestOption) (res AddressResponse, err error) {
+	return call[addressRequest, AddressResponse](ctx, c, addressZipEndpoint, newAddressRequest(opts...))
+}
 
-	return
+// AddressZipWithMeta は [Client.AddressZipContext] と同じ検索を行い、レスポンスの情報もあわせて返します。
+//
+// 戻り値:
+//   - AddressResponse: 検索結果
+//   - Meta: ステータスコード、ヘッダ、ボディなどのレスポンスの情報（エラーの場合もレスポンスを受信していれば設定されます）
+//   - error: 通信エラー、ステータスコード異常、デコード失敗など
+func (c *Client) AddressZipWithMeta(ctx context.Context, opts ...addressRequestOption) (AddressResponse, Meta, error) {
+	return callWithMeta[addressRequest, AddressResponse](ctx, c, addressZipEndpoint, newAddressRequest(opts...))
 }
@@ -43,6 +43,13 @@ func TestNewAddressRequest(t *testing.T) {
 			wantPage:     2,
 			wantLimit:    50,
 		},
+		{
+			name: "freeword sent verbatim",
+			opts: []yd4b.AddressRequestOption{
+				yd4b.WithFreeword("ﾁｭｳｵｳｸ１"),
+			},
+			wantFreeword: "ﾁｭｳｵｳｸ１",
+		},
 	}
 
 	for _, tc := range tests {
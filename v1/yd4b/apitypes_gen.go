@@ -0,0 +1,104 @@
+// Code generated by gen from openapi.json. DO NOT EDIT.
+
+package yd4b
+
+import "encoding/json"
+
+// TokenRequest は API 利用トークン取得のためのリクエストボディです。
+type TokenRequest struct {
+	GrantType string `json:"grant_type"` // 認可タイプ（client_credentials 固定）
+	ClientID  string `json:"client_id"`  // クライアントID
+	SecretKey string `json:"secret_key"` // シークレットキー
+}
+
+// TokenResponse はトークン取得APIのレスポンス形式です。
+type TokenResponse struct {
+	Scope     string `json:"scope"`      // トークンスコープ（例: "J1"）
+	TokenType string `json:"token_type"` // トークンタイプ（例: "Bearer"）
+	ExpiresIn int64  `json:"expires_in"` // 有効期限（秒数）
+	Token     string `json:"token"`      // アクセストークン（"Token: " プレフィックス付きの場合あり）
+}
+
+// SearchcodeResponse はコード番号検索のレスポンスを表す構造体です。
+type SearchcodeResponse struct {
+	Page       int                     `json:"page"`       // ページ数
+	Limit      int                     `json:"limit"`      // 取得最大レコード数
+	Count      int                     `json:"count"`      // 該当データ数
+	Searchtype string                  `json:"searchtype"` // 検索タイプ（"dgacode" / "zipcode" / "bizzipcode"）
+	Addresses  []SearchcodeAddressItem `json:"addresses"`  // 検索結果の住所情報一覧
+}
+
+// SearchcodeAddressItem はコード番号検索結果の各アイテムを表す構造体です。
+type SearchcodeAddressItem struct {
+	DgaCode   *string  `json:"dgacode"`    // デジタルアドレスコード（nullable）
+	ZipCode   string   `json:"zip_code"`   // 郵便番号
+	PrefCode  string   `json:"pref_code"`  // 都道府県コード
+	PrefName  string   `json:"pref_name"`  // 都道府県名
+	PrefKana  *string  `json:"pref_kana"`  // 都道府県名カナ（nullable）
+	PrefRoma  *string  `json:"pref_roma"`  // 都道府県名ローマ字（nullable）
+	CityCode  string   `json:"city_code"`  // 市区町村コード
+	CityName  string   `json:"city_name"`  // 市区町村名
+	CityKana  *string  `json:"city_kana"`  // 市区町村名カナ（nullable）
+	CityRoma  *string  `json:"city_roma"`  // 市区町村名ローマ字（nullable）
+	TownName  string   `json:"town_name"`  // 町域名
+	TownKana  *string  `json:"town_kana"`  // 町域名カナ（nullable）
+	TownRoma  *string  `json:"town_roma"`  // 町域名ローマ字（nullable）
+	BizName   *string  `json:"biz_name"`   // 事業所名（nullable）
+	BizKana   *string  `json:"biz_kana"`   // 事業所名カナ（nullable）
+	BizRoma   *string  `json:"biz_roma"`   // 事業所名ローマ字（nullable）
+	BlockName *string  `json:"block_name"` // 町域字等（nullable）
+	OtherName *string  `json:"other_name"` // その他名称（nullable）
+	Address   *string  `json:"address"`    // 住所（nullable）
+	Longitude *float64 `json:"longitude"`  // 経度（nullable）
+	Latitude  *float64 `json:"latitude"`   // 緯度（nullable）
+
+	Extras map[string]json.RawMessage `json:"-"` // 構造体に定義されていないフィールド（ない場合は nil）
+}
+
+// addressRequest は住所情報をもとに郵便番号を検索するための内部リクエスト構造体です。
+// 各フィールドは API リクエストの JSON ボディにマッピングされます。
+type addressRequest struct {
+	PrefCode   string `json:"pref_code,omitempty"`   // 都道府県コード
+	PrefName   string `json:"pref_name,omitempty"`   // 都道府県名
+	PrefKana   string `json:"pref_kana,omitempty"`   // 都道府県名（カナ）
+	PrefRoma   string `json:"pref_roma,omitempty"`   // 都道府県名（ローマ字）
+	CityCode   string `json:"city_code,omitempty"`   // 市区町村コード
+	CityName   string `json:"city_name,omitempty"`   // 市区町村名
+	CityKana   string `json:"city_kana,omitempty"`   // 市区町村名（カナ）
+	CityRoma   string `json:"city_roma,omitempty"`   // 市区町村名（ローマ字）
+	TownName   string `json:"town_name,omitempty"`   // 町域名
+	TownKana   string `json:"town_kana,omitempty"`   // 町域名（カナ）
+	TownRoma   string `json:"town_roma,omitempty"`   // 町域名（ローマ字）
+	Freeword   string `json:"freeword,omitempty"`    // フリーワード検索
+	FlgGetCity int    `json:"flg_getcity,omitempty"` // 市区町村一覧取得フラグ（1: 有効）
+	FlgGetPref int    `json:"flg_getpref,omitempty"` // 都道府県一覧取得フラグ（1: 有効）
+	Page       int    `json:"page,omitempty"`        // ページ番号
+	Limit      int    `json:"limit,omitempty"`       // 取得件数の上限
+}
+
+// AddressResponse は住所から検索した郵便番号結果を表す構造体です。
+type AddressResponse struct {
+	Level     int           `json:"level"`     // 検索レベル
+	Page      int           `json:"page"`      // 現在のページ番号
+	Limit     int           `json:"limit"`     // １ページあたりの件数
+	Count     int           `json:"count"`     // 総件数
+	Addresses []AddressItem `json:"addresses"` // 検索結果の住所データ一覧
+}
+
+// AddressItem は住所検索結果の各アイテム（郵便番号含む）を表す構造体です。
+type AddressItem struct {
+	ZipCode  string `json:"zip_code"`  // 郵便番号
+	PrefCode string `json:"pref_code"` // 都道府県コード
+	PrefName string `json:"pref_name"` // 都道府県名
+	PrefKana string `json:"pref_kana"` // 都道府県名（カナ）
+	PrefRoma string `json:"pref_roma"` // 都道府県名（ローマ字）
+	CityCode string `json:"city_code"` // 市区町村コード
+	CityName string `json:"city_name"` // 市区町村名
+	CityKana string `json:"city_kana"` // 市区町村名（カナ）
+	CityRoma string `json:"city_roma"` // 市区町村名（ローマ字）
+	TownName string `json:"town_name"` // 町域名
+	TownKana string `json:"town_kana"` // 町域名（カナ）
+	TownRoma string `json:"town_roma"` // 町域名（ローマ字）
+
+	Extras map[string]json.RawMessage `json:"-"` // 構造体に定義されていないフィールド（ない場合は nil）
+}
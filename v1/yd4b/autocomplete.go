@@ -0,0 +1,465 @@
+package yd4b
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b/normalize"
+)
+
+// ErrSuggestionSuperseded は同じセッションでより新しい入力が行われたため、古い補完要求が破棄されたことを表すエラーです。
+var ErrSuggestionSuperseded = NewError(http.StatusConflict, "suggestion superseded by newer input")
+
+// SuggestionLevel は補完候補の階層（都道府県・市区町村・町域）を表す型です。
+type SuggestionLevel int
+
+const (
+	SuggestionPref SuggestionLevel = iota + 1 // 都道府県
+	SuggestionCity                            // 市区町村
+	SuggestionTown                            // 町域
+)
+
+// Suggestion は住所補完の候補を表す構造体です。
+type Suggestion struct {
+	Level    SuggestionLevel `json:"level"`               // 候補の階層
+	Label    string          `json:"label"`               // 表示用の住所文字列
+	ZipCode  string          `json:"zip_code,omitempty"`  // 郵便番号（町域の候補のみ）
+	PrefCode string          `json:"pref_code"`           // 都道府県コード
+	PrefName string          `json:"pref_name"`           // 都道府県名
+	CityCode string          `json:"city_code,omitempty"` // 市区町村コード
+	CityName string          `json:"city_name,omitempty"` // 市区町村名
+	TownName string          `json:"town_name,omitempty"` // 町域名
+	Score    float64         `json:"score"`               // 入力との一致度（0〜1）
+}
+
+// autocompleteOption は Autocompleter にオプションを適用するためのインターフェースです。
+type autocompleteOption interface {
+	apply(*Autocompleter)
+}
+
+type autocompleteOptionFunc func(*Autocompleter)
+
+func (f autocompleteOptionFunc) apply(a *Autocompleter) { f(a) }
+
+// WithDebounce は入力から問い合わせまでの待機時間を指定するオプションです。
+func WithDebounce(d time.Duration) autocompleteOption {
+	return autocompleteOptionFunc(func(a *Autocompleter) {
+		a.debounce = d
+	})
+}
+
+// WithACLimit は補完のために addresszip へ問い合わせる際の取得件数の上限を指定するオプションです。
+func WithACLimit(limit int) autocompleteOption {
+	return autocompleteOptionFunc(func(a *Autocompleter) {
+		a.limit = limit
+	})
+}
+
+// WithMaxSuggestions は返す補完候補の最大件数を指定するオプションです。
+func WithMaxSuggestions(n int) autocompleteOption {
+	return autocompleteOptionFunc(func(a *Autocompleter) {
+		a.maxSuggestions = n
+	})
+}
+
+// WithMinInputLength は問い合わせを行う入力の最小文字数を指定するオプションです。
+func WithMinInputLength(n int) autocompleteOption {
+	return autocompleteOptionFunc(func(a *Autocompleter) {
+		a.minLength = n
+	})
+}
+
+// WithCacheSize は入力ごとの検索結果を保持するキャッシュの件数を指定するオプションです。
+func WithCacheSize(n int) autocompleteOption {
+	return autocompleteOptionFunc(func(a *Autocompleter) {
+		a.cacheSize = n
+	})
+}
+
+// acCacheEntry は入力文字列ごとの検索結果のキャッシュです。
+type acCacheEntry struct {
+	items    []AddressItem
+	complete bool // 該当データを全件取得できているか
+}
+
+// acSession は入力欄ごとの進行中の補完要求を管理します。
+type acSession struct {
+	seq    uint64
+	cancel context.CancelCauseFunc
+}
+
+// Autocompleter は addresszip のフリーワード検索を用いた住所の入力補完を提供します。
+// デバウンス、古い要求のキャンセル、入力の前方一致によるキャッシュを行います。
+// 複数のゴルーチンから安全に利用できます。
+type Autocompleter struct {
+	client         *Client
+	debounce       time.Duration
+	limit          int
+	maxSuggestions int
+	minLength      int
+	cacheSize      int
+
+	mu         sync.Mutex
+	cache      map[string]acCacheEntry
+	cacheOrder []string
+	sessions   map[string]*acSession
+}
+
+// NewAutocompleter は [Autocompleter] を生成します。
+//
+// 引数:
+//   - client: 問い合わせに使用するクライアント
+//   - opts: デバウンス時間や取得件数などのオプション
+func NewAutocompleter(client *Client, opts ...autocompleteOption) *Autocompleter {
+	a := &Autocompleter{
+		client:         client,
+		debounce:       200 * time.Millisecond,
+		limit:          100,
+		maxSuggestions: 10,
+		minLength:      1,
+		cacheSize:      256,
+		cache:          make(map[string]acCacheEntry),
+		sessions:       make(map[string]*acSession),
+	}
+	for _, opt := range opts {
+		opt.apply(a)
+	}
+	return a
+}
+
+// Suggest は入力文字列に対する補完候補を返します。
+// 同じ session で新しい入力が行われると、古い要求は [ErrSuggestionSuperseded] で終了します。
+// session が空文字列の場合、他の要求のキャンセルは行いません。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - session: 入力欄やユーザーを識別するキー
+//   - input: 入力中の文字列
+//
+// 戻り値:
+//   - []Suggestion: 一致度の高い順に並んだ補完候補
+//   - error: 通信エラーやキャンセルなど
+func (a *Autocompleter) Suggest(ctx context.Context, session string, input string) ([]Suggestion, error) {
+	// 入力が最小文字数未満に短くなった場合も、以前の要求はキャンセルする
+	ctx, done := a.begin(ctx, session)
+	defer done()
+
+	q := normalize.Input(input)
+	if len([]rune(q)) < a.minLength {
+		return []Suggestion{}, nil
+	}
+
+	if a.debounce > 0 {
+		timer := time.NewTimer(a.debounce)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, context.Cause(ctx)
+		}
+	}
+
+	items, err := a.lookup(ctx, q)
+	if err != nil {
+		if cause := context.Cause(ctx); cause != nil {
+			return nil, cause
+		}
+		return nil, err
+	}
+	return a.rank(q, items), nil
+}
+
+// begin はセッションに新しい要求を登録し、以前の要求をキャンセルします。
+func (a *Autocompleter) begin(parent context.Context, session string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	if session == "" {
+		return ctx, func() { cancel(nil) }
+	}
+
+	a.mu.Lock()
+	s, ok := a.sessions[session]
+	if !ok {
+		s = &acSession{}
+		a.sessions[session] = s
+	}
+	if s.cancel != nil {
+		s.cancel(ErrSuggestionSuperseded)
+	}
+	s.seq++
+	seq := s.seq
+	s.cancel = cancel
+	a.mu.Unlock()
+
+	return ctx, func() {
+		cancel(nil)
+		a.mu.Lock()
+		if s.seq == seq {
+			delete(a.sessions, session)
+		}
+		a.mu.Unlock()
+	}
+}
+
+// lookup はキャッシュを参照し、必要な場合のみ addresszip に問い合わせます。
+func (a *Autocompleter) lookup(ctx context.Context, q string) ([]AddressItem, error) {
+	if items, ok := a.cached(q); ok {
+		return items, nil
+	}
+
+	res, err := a.client.AddressZipContext(ctx, WithFreeword(q), WithAZLimit(a.limit))
+	if err != nil {
+		return nil, err
+	}
+
+	a.store(q, acCacheEntry{items: res.Addresses, complete: res.Count <= len(res.Addresses)})
+	return res.Addresses, nil
+}
+
+// cached は入力文字列に対するキャッシュを返します。
+// 完全一致がない場合でも、全件取得済みの前方一致エントリがあれば絞り込んで返します。
+func (a *Autocompleter) cached(q string) ([]AddressItem, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if e, ok := a.cache[q]; ok {
+		return e.items, true
+	}
+
+	best := ""
+	for key, e := range a.cache {
+		if e.complete && strings.HasPrefix(q, key) && len(key) > len(best) {
+			best = key
+		}
+	}
+	if best == "" {
+		return nil, false
+	}
+
+	var items []AddressItem
+	for _, item := range a.cache[best].items {
+		if matchesAddressItem(item, q) {
+			items = append(items, item)
+		}
+	}
+	return items, true
+}
+
+// store は検索結果をキャッシュに保存し、上限を超えた古いエントリを削除します。
+func (a *Autocompleter) store(q string, e acCacheEntry) {
+	if a.cacheSize <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.cache[q]; !ok {
+		a.cacheOrder = append(a.cacheOrder, q)
+	}
+	a.cache[q] = e
+	for len(a.cacheOrder) > a.cacheSize {
+		delete(a.cache, a.cacheOrder[0])
+		a.cacheOrder = a.cacheOrder[1:]
+	}
+}
+
+// matchesAddressItem は住所アイテムが入力文字列に一致するかどうかを判定します。
+// 漢字・カナ・ローマ字の各フィールドを正規化したうえで比較するため、
+// 最初の問い合わせでカナやローマ字により一致した候補も絞り込みで失われません。
+func matchesAddressItem(item AddressItem, q string) bool {
+	if key := normalize.Key(q); key != "" {
+		for _, f := range []string{
+			item.PrefName + item.CityName + item.TownName,
+			item.CityName + item.TownName,
+			item.TownName,
+		} {
+			if strings.Contains(normalize.Key(f), key) {
+				return true
+			}
+		}
+	}
+	if kana := kanaKey(q); kana != "" {
+		for _, f := range []string{
+			item.PrefKana + item.CityKana + item.TownKana,
+			item.CityKana + item.TownKana,
+			item.TownKana,
+		} {
+			if strings.Contains(kanaKey(f), kana) {
+				return true
+			}
+		}
+	}
+	if roma := romaKey(q); roma != "" {
+		for _, f := range []string{
+			item.PrefRoma + item.CityRoma + item.TownRoma,
+			item.CityRoma + item.TownRoma,
+			item.TownRoma,
+		} {
+			if strings.Contains(romaKey(f), roma) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// kanaKey はカナの比較に用いるキー文字列を返します。
+// 半角カナ・ひらがなをカタカナに統一し、空白を除去します。
+func kanaKey(s string) string {
+	return strings.Join(strings.Fields(normalize.Katakana(s)), "")
+}
+
+// romaKey はローマ字の比較に用いるキー文字列を返します。
+// 英数字のみを小文字で残し、空白やハイフンなどの区切りは除去します。
+func romaKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return -1
+	}, normalize.NFKC(s))
+}
+
+// matchScore は名称と入力文字列の一致度を返します。
+func matchScore(name, q string) float64 {
+	switch {
+	case name == "":
+		return 0
+	case name == q:
+		return 1
+	case strings.HasPrefix(name, q):
+		return 0.8
+	case strings.Contains(name, q):
+		return 0.5
+	case strings.HasPrefix(q, name):
+		return 0.4
+	default:
+		return 0
+	}
+}
+
+// rank は住所アイテムから都道府県・市区町村・町域の階層的な候補を生成し、一致度順に並べます。
+func (a *Autocompleter) rank(q string, items []AddressItem) []Suggestion {
+	seen := make(map[string]int)
+	suggestions := []Suggestion{}
+	add := func(key string, s Suggestion) {
+		if i, ok := seen[key]; ok {
+			if s.Score > suggestions[i].Score {
+				suggestions[i].Score = s.Score
+			}
+			return
+		}
+		seen[key] = len(suggestions)
+		suggestions = append(suggestions, s)
+	}
+
+	for _, item := range items {
+		prefScore := matchScore(item.PrefName, q)
+		if prefScore > 0 {
+			add("p:"+item.PrefCode, Suggestion{
+				Level:    SuggestionPref,
+				Label:    item.PrefName,
+				PrefCode: item.PrefCode,
+				PrefName: item.PrefName,
+				Score:    prefScore,
+			})
+		}
+
+		cityScore := max(matchScore(item.CityName, q), matchScore(item.PrefName+item.CityName, q))
+		if item.CityName != "" && cityScore > 0 {
+			add("c:"+item.CityCode, Suggestion{
+				Level:    SuggestionCity,
+				Label:    item.PrefName + item.CityName,
+				PrefCode: item.PrefCode,
+				PrefName: item.PrefName,
+				CityCode: item.CityCode,
+				CityName: item.CityName,
+				Score:    cityScore * 0.95,
+			})
+		}
+
+		if item.TownName == "" && item.ZipCode == "" {
+			continue
+		}
+		townScore := max(
+			matchScore(item.TownName, q),
+			matchScore(item.CityName+item.TownName, q),
+			matchScore(item.PrefName+item.CityName+item.TownName, q),
+			matchScore(item.TownKana, q),
+		)
+		if townScore == 0 {
+			// API側でカナ等により一致した候補は低い一致度で残す
+			townScore = 0.2
+		}
+		add("t:"+item.ZipCode+":"+item.CityCode+":"+item.TownName, Suggestion{
+			Level:    SuggestionTown,
+			Label:    item.PrefName + item.CityName + item.TownName,
+			ZipCode:  item.ZipCode,
+			PrefCode: item.PrefCode,
+			PrefName: item.PrefName,
+			CityCode: item.CityCode,
+			CityName: item.CityName,
+			TownName: item.TownName,
+			Score:    townScore * 0.9,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		if suggestions[i].Level != suggestions[j].Level {
+			return suggestions[i].Level < suggestions[j].Level
+		}
+		return suggestions[i].Label < suggestions[j].Label
+	})
+	if a.maxSuggestions > 0 && len(suggestions) > a.maxSuggestions {
+		suggestions = suggestions[:a.maxSuggestions]
+	}
+	return suggestions
+}
+
+// ServeHTTP は補完候補を JSON で返す HTTP ハンドラです。
+// クエリパラメータ q に入力文字列、session に入力欄を識別するキーを指定します。
+//
+// レスポンス例:
+//
+//	{"suggestions":[{"level":3,"label":"東京都千代田区千代田","zip_code":"1000001",...}]}
+func (a *Autocompleter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorJSON(w, NewError(http.StatusMethodNotAllowed, "method not allowed"))
+		return
+	}
+
+	suggestions, err := a.Suggest(r.Context(), r.URL.Query().Get("session"), r.URL.Query().Get("q"))
+	if err != nil {
+		writeErrorJSON(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Suggestions []Suggestion `json:"suggestions"`
+	}{suggestions})
+}
+
+// writeErrorJSON はエラーを JSON で書き出します。
+// [Error] 以外のエラーは 500 として扱います。
+func writeErrorJSON(w http.ResponseWriter, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = NewError(http.StatusInternalServerError, "internal server error")
+	}
+	b, _ := e.ToJSON()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.StatusCode)
+	_, _ = w.Write(b)
+}
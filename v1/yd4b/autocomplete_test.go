@@ -0,0 +1,217 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+const autocompleteBody = `{"level":3,"page":1,"limit":100,"count":2,"addresses":[
+	{"zip_code":"1000001","pref_code":"13","pref_name":"東京都","city_code":"13101","city_name":"千代田区","town_name":"千代田"},
+	{"zip_code":"1010021","pref_code":"13","pref_name":"東京都","city_code":"13101","city_name":"千代田区","town_name":"外神田"}]}`
+
+func newAutocompleteClient(calls *int32, body string) *yd4b.Client {
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	})
+	return client
+}
+
+func TestAutocompleter_Suggest(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantLabels []string
+		wantCalls  int32
+	}{
+		{
+			name:       "hierarchy suggestions",
+			input:      "千代田",
+			wantLabels: []string{"東京都千代田区千代田", "東京都千代田区", "東京都千代田区外神田"},
+			wantCalls:  1,
+		},
+		{
+			name:       "below min length",
+			input:      " ",
+			wantLabels: []string{},
+			wantCalls:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			ac := yd4b.NewAutocompleter(newAutocompleteClient(&calls, autocompleteBody), yd4b.WithDebounce(0))
+
+			got, err := ac.Suggest(context.Background(), "s", tt.input)
+			assert.NoError(t, err)
+			labels := []string{}
+			for _, s := range got {
+				labels = append(labels, s.Label)
+			}
+			assert.Equal(t, tt.wantLabels, labels)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestAutocompleter_Cache(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	ac := yd4b.NewAutocompleter(newAutocompleteClient(&calls, autocompleteBody), yd4b.WithDebounce(0), yd4b.WithMaxSuggestions(1))
+	ctx := context.Background()
+
+	_, err := ac.Suggest(ctx, "", "千代")
+	assert.NoError(t, err)
+	_, err = ac.Suggest(ctx, "", "千代")
+	assert.NoError(t, err)
+
+	// 全件取得済みの前方一致キャッシュから絞り込まれる
+	got, err := ac.Suggest(ctx, "", "千代田区外")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Len(t, got, 1)
+	assert.Equal(t, "1010021", got[0].ZipCode)
+}
+
+const autocompleteKanaBody = `{"level":3,"page":1,"limit":100,"count":2,"addresses":[
+	{"zip_code":"1000001","pref_code":"13","pref_name":"東京都","pref_kana":"トウキョウト","pref_roma":"TOKYO","city_code":"13101","city_name":"千代田区","city_kana":"チヨダク","city_roma":"CHIYODA-KU","town_name":"千代田","town_kana":"ﾁﾖﾀﾞ","town_roma":"CHIYODA"},
+	{"zip_code":"1010021","pref_code":"13","pref_name":"東京都","pref_kana":"トウキョウト","pref_roma":"TOKYO","city_code":"13101","city_name":"千代田区","city_kana":"チヨダク","city_roma":"CHIYODA-KU","town_name":"外神田","town_kana":"ｿﾄｶﾝﾀﾞ","town_roma":"SOTOKANDA"}]}`
+
+func TestAutocompleter_CacheKanaRoma(t *testing.T) {
+	tests := []struct {
+		name     string
+		first    string
+		next     string
+		wantZips []string
+	}{
+		{name: "hiragana", first: "ちよ", next: "ちよだ", wantZips: []string{"1000001", "1010021"}},
+		{name: "katakana", first: "ソト", next: "ソトカ", wantZips: []string{"1010021"}},
+		{name: "half-width katakana", first: "ｿﾄ", next: "ｿﾄｶ", wantZips: []string{"1010021"}},
+		{name: "romaji", first: "soto", next: "sotok", wantZips: []string{"1010021"}},
+		{name: "romaji with city", first: "chiyoda", next: "chiyoda-ku c", wantZips: []string{"1000001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			ac := yd4b.NewAutocompleter(newAutocompleteClient(&calls, autocompleteKanaBody), yd4b.WithDebounce(0))
+			ctx := context.Background()
+
+			_, err := ac.Suggest(ctx, "", tt.first)
+			assert.NoError(t, err)
+			// カナやローマ字でのみ一致する候補も、前方一致キャッシュの絞り込みで残る
+			got, err := ac.Suggest(ctx, "", tt.next)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+			zips := []string{}
+			for _, s := range got {
+				if s.Level == yd4b.SuggestionTown {
+					zips = append(zips, s.ZipCode)
+				}
+			}
+			assert.ElementsMatch(t, tt.wantZips, zips)
+		})
+	}
+}
+
+func TestAutocompleter_Superseded(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	ac := yd4b.NewAutocompleter(newAutocompleteClient(&calls, autocompleteBody), yd4b.WithDebounce(200*time.Millisecond))
+
+	var wg sync.WaitGroup
+	var staleErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, staleErr = ac.Suggest(context.Background(), "user-1", "千")
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	got, err := ac.Suggest(context.Background(), "user-1", "千代田")
+	wg.Wait()
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, got)
+	assert.True(t, errors.Is(staleErr, yd4b.ErrSuggestionSuperseded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestAutocompleter_SupersededByShortInput(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	ac := yd4b.NewAutocompleter(newAutocompleteClient(&calls, autocompleteBody), yd4b.WithDebounce(200*time.Millisecond))
+
+	var wg sync.WaitGroup
+	var staleErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, staleErr = ac.Suggest(context.Background(), "user-1", "東京")
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// 入力をすべて削除した場合も、以前の要求はキャンセルされる
+	got, err := ac.Suggest(context.Background(), "user-1", "")
+	wg.Wait()
+
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	assert.True(t, errors.Is(staleErr, yd4b.ErrSuggestionSuperseded))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestAutocompleter_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+	}{
+		{name: "success", method: http.MethodGet, body: autocompleteBody, wantStatus: http.StatusOK},
+		{name: "method not allowed", method: http.MethodPost, body: autocompleteBody, wantStatus: http.StatusMethodNotAllowed},
+		{name: "upstream decode error", method: http.MethodGet, body: `{bad}`, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			ac := yd4b.NewAutocompleter(newAutocompleteClient(&calls, tt.body), yd4b.WithDebounce(0))
+			rec := httptest.NewRecorder()
+			ac.ServeHTTP(rec, httptest.NewRequest(tt.method, "/autocomplete?q=%E5%8D%83%E4%BB%A3%E7%94%B0", nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			if tt.wantStatus == http.StatusOK {
+				var res struct {
+					Suggestions []yd4b.Suggestion `json:"suggestions"`
+				}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.NotEmpty(t, res.Suggestions)
+			}
+		})
+	}
+}
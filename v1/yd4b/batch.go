@@ -0,0 +1,124 @@
+package yd4b
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBatchConcurrency は [BatchOptions.Concurrency] が指定されていない場合の並列数です。
+const defaultBatchConcurrency = 4
+
+// BatchOptions は [Client.BatchSearchcode] の動作を指定する構造体です。
+type BatchOptions struct {
+	Concurrency       int                   // 同時に実行するリクエスト数（0以下の場合は4）
+	StopOnError       bool                  // 最初のエラーで残りの検索を中止するかどうか
+	Progress          func(done, total int) // 進捗を受け取るコールバック（nil 可、同時には呼び出されません）
+	SearchcodeOptions []searchcodeOption    // 各検索に適用するオプション
+}
+
+// BatchResult は一括検索における1件分の結果を表す構造体です。
+type BatchResult struct {
+	Code     string             // 検索したコード
+	Response SearchcodeResponse // 検索結果
+	Err      error              // この検索のエラー
+}
+
+// BatchSearchcode は複数のコードをワーカープールで並列に検索し、入力と同じ順序で結果を返します。
+// 重複するコードは1度だけ検索され、結果が共有されます。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - codes: 検索するコードの一覧
+//   - opts: 並列数や中止条件などのオプション
+//
+// 戻り値:
+//   - []BatchResult: codes と同じ順序の結果（各要素がそれぞれのエラーを持ちます）
+//   - error: StopOnError が有効な場合の最初のエラー、またはコンテキストのエラー
+func (c *Client) BatchSearchcode(ctx context.Context, codes []string, opts BatchOptions) ([]BatchResult, error) {
+	results := make([]BatchResult, len(codes))
+
+	// 重複を除いたコードと、それぞれの入力位置
+	positions := make(map[string][]int)
+	var unique []string
+	for i, code := range codes {
+		results[i].Code = code
+		if _, ok := positions[code]; !ok {
+			unique = append(unique, code)
+		}
+		positions[code] = append(positions[code], i)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	concurrency = min(concurrency, len(unique))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		done     int
+		firstErr error
+		finished = make(map[string]bool, len(unique))
+	)
+	record := func(code string, res SearchcodeResponse, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		finished[code] = true
+		for _, i := range positions[code] {
+			results[i].Response = res
+			results[i].Err = err
+		}
+		if err != nil && opts.StopOnError && firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		done += len(positions[code])
+		if opts.Progress != nil {
+			opts.Progress(done, len(codes))
+		}
+	}
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for code := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				res, err := c.SearchcodeContext(ctx, code, opts.SearchcodeOptions...)
+				record(code, res, err)
+			}
+		}()
+	}
+
+feed:
+	for _, code := range unique {
+		select {
+		case jobs <- code:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// 中止により検索されなかったコードにはコンテキストのエラーを設定する
+	for _, code := range unique {
+		if !finished[code] {
+			for _, i := range positions[code] {
+				results[i].Err = ctx.Err()
+			}
+		}
+	}
+
+	if firstErr != nil {
+		return results, firstErr
+	}
+	return results, context.Cause(ctx)
+}
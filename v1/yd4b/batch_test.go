@@ -0,0 +1,121 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+// batchDoFunc はコードをそのまま zip_code として返し、"bad" で始まるコードには 404 を返す doFunc です。
+func batchDoFunc(calls *int32) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+		code := path.Base(req.URL.Path)
+		if len(code) >= 3 && code[:3] == "bad" {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+		}
+		body := fmt.Sprintf(`{"count":1,"addresses":[{"zip_code":%q}]}`, code)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	}
+}
+
+func TestClient_BatchSearchcode(t *testing.T) {
+	tests := []struct {
+		name        string
+		codes       []string
+		opts        yd4b.BatchOptions
+		wantCalls   int32
+		wantErr     bool
+		wantItemErr []bool
+	}{
+		{
+			name:        "ordered and deduplicated",
+			codes:       []string{"3", "1", "2", "1", "3"},
+			opts:        yd4b.BatchOptions{Concurrency: 2},
+			wantCalls:   3,
+			wantItemErr: []bool{false, false, false, false, false},
+		},
+		{
+			name:        "per item errors",
+			codes:       []string{"1", "bad1", "2"},
+			opts:        yd4b.BatchOptions{Concurrency: 1},
+			wantCalls:   3,
+			wantItemErr: []bool{false, true, false},
+		},
+		{
+			name:        "stop on error",
+			codes:       []string{"bad1", "1", "2", "3"},
+			opts:        yd4b.BatchOptions{Concurrency: 1, StopOnError: true},
+			wantErr:     true,
+			wantItemErr: []bool{true, true, true, true},
+		},
+		{
+			name:  "empty",
+			codes: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(batchDoFunc(&calls))
+
+			var mu sync.Mutex
+			var progress []int
+			tt.opts.Progress = func(done, total int) {
+				mu.Lock()
+				defer mu.Unlock()
+				assert.Equal(t, len(tt.codes), total)
+				progress = append(progress, done)
+			}
+
+			results, err := client.BatchSearchcode(context.Background(), tt.codes, tt.opts)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "unexpected status code")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+				if len(tt.codes) > 0 {
+					assert.Equal(t, len(tt.codes), progress[len(progress)-1])
+				}
+			}
+
+			assert.Len(t, results, len(tt.codes))
+			for i, r := range results {
+				assert.Equal(t, tt.codes[i], r.Code)
+				assert.Equal(t, tt.wantItemErr[i], r.Err != nil, r.Code)
+				if r.Err == nil {
+					assert.Equal(t, tt.codes[i], r.Response.Addresses[0].ZipCode)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_BatchSearchcode_Canceled(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(batchDoFunc(&calls))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := client.BatchSearchcode(ctx, []string{"1", "2"}, yd4b.BatchOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	for _, r := range results {
+		assert.Error(t, r.Err)
+	}
+}
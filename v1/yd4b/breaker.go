@@ -0,0 +1,262 @@
+package yd4b
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen はサーキットブレーカーが開いているためにリクエストを送信しなかった場合のエラーです。
+var ErrCircuitOpen = NewError(http.StatusServiceUnavailable, "circuit breaker is open")
+
+// BreakerState はサーキットブレーカーの状態です。
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // リクエストを送信する（通常の状態）
+	BreakerOpen                         // リクエストを送信せずに ErrCircuitOpen を返す
+	BreakerHalfOpen                     // 回復を確認するため、一部のリクエストだけを送信する
+)
+
+// String は状態の名前を返します。
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerPolicy は [NewCircuitBreaker] の設定です。ゼロ値のフィールドには既定値が使われます。
+type BreakerPolicy struct {
+	FailureRate    float64                     // 直近のリクエストの失敗率がこの値以上になったら開く（既定値は 0.5）
+	MinRequests    int                         // 失敗率を判定するのに必要な最小のリクエスト数（既定値は 10）
+	WindowSize     int                         // 失敗率の計算に使う直近のリクエスト数（既定値は 20）
+	OpenTimeout    time.Duration               // 開いてから半開きにするまでの時間（既定値は 30秒）
+	HalfOpenProbes int                         // 半開きの状態で送信するリクエスト数。すべて成功したら閉じる（既定値は 1）
+	OnStateChange  func(from, to BreakerState) // 状態が変化したときに呼び出される関数（nil の場合は呼び出さない）
+}
+
+// CircuitBreaker は API の障害時にリクエストを送信せずに失敗させるサーキットブレーカーです。
+// 複数の [Client] で共有できます。
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	policy    BreakerPolicy
+	state     BreakerState
+	gen       uint64 // 状態が変化した回数（古い状態で送信したリクエストの結果を無視するために使う）
+	window    []bool // 直近のリクエストの結果（true が失敗）
+	next      int    // window に次の結果を書き込む位置
+	count     int    // window に記録されている結果の数
+	failures  int    // window に記録されている失敗の数
+	openedAt  time.Time
+	probes    int // 半開きの状態で送信中のリクエスト数
+	successes int // 半開きの状態で成功したリクエスト数
+	now       func() time.Time
+}
+
+// NewCircuitBreaker は新しい CircuitBreaker を生成します。
+//
+// 引数:
+//   - policy: 開閉の条件と状態変化の通知先
+//
+// 戻り値:
+//   - *CircuitBreaker: 閉じた状態の CircuitBreaker
+func NewCircuitBreaker(policy BreakerPolicy) *CircuitBreaker {
+	if policy.FailureRate <= 0 {
+		policy.FailureRate = 0.5
+	}
+	if policy.MinRequests <= 0 {
+		policy.MinRequests = 10
+	}
+	if policy.WindowSize <= 0 {
+		policy.WindowSize = 20
+	}
+	policy.WindowSize = max(policy.WindowSize, policy.MinRequests)
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = 30 * time.Second
+	}
+	if policy.HalfOpenProbes <= 0 {
+		policy.HalfOpenProbes = 1
+	}
+	return &CircuitBreaker{
+		policy: policy,
+		window: make([]bool, policy.WindowSize),
+		now:    time.Now,
+	}
+}
+
+// State は現在の状態を返します。開いてから OpenTimeout が経過している場合は半開きとして返します。
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.policy.OpenTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// breakerOutcome はリクエストの結果の分類です。
+type breakerOutcome int
+
+const (
+	outcomeSuccess breakerOutcome = iota // 成功
+	outcomeFailure                       // 失敗率に数える失敗
+	outcomeIgnored                       // 呼び出し元のキャンセルなど、失敗率に数えない結果
+)
+
+// breakerTransition は状態の変化です。
+type breakerTransition struct {
+	from, to BreakerState
+}
+
+// allow はリクエストを送信してよいかを判定します。
+// 送信してよい場合は、結果を記録する関数を返します。
+func (b *CircuitBreaker) allow() (done func(breakerOutcome), err error) {
+	b.mu.Lock()
+	var changes []breakerTransition
+	defer func() {
+		b.mu.Unlock()
+		b.notify(changes)
+	}()
+
+	if b.state == BreakerOpen {
+		if b.now().Sub(b.openedAt) < b.policy.OpenTimeout {
+			return nil, ErrCircuitOpen
+		}
+		changes = append(changes, b.setState(BreakerHalfOpen))
+	}
+	probe := b.state == BreakerHalfOpen
+	if probe {
+		if b.probes >= b.policy.HalfOpenProbes {
+			return nil, ErrCircuitOpen
+		}
+		b.probes++
+	}
+
+	gen := b.gen
+	return func(o breakerOutcome) { b.record(gen, probe, o) }, nil
+}
+
+// record はリクエストの結果を記録し、必要に応じて状態を変化させます。
+func (b *CircuitBreaker) record(gen uint64, probe bool, o breakerOutcome) {
+	b.mu.Lock()
+	var changes []breakerTransition
+	defer func() {
+		b.mu.Unlock()
+		b.notify(changes)
+	}()
+
+	// 送信した後に状態が変化していた場合、その結果は現在の状態の判定に使わない
+	if gen != b.gen {
+		return
+	}
+
+	if probe {
+		b.probes--
+		switch o {
+		case outcomeFailure:
+			changes = append(changes, b.setState(BreakerOpen))
+		case outcomeSuccess:
+			b.successes++
+			if b.successes >= b.policy.HalfOpenProbes {
+				changes = append(changes, b.setState(BreakerClosed))
+			}
+		}
+		return
+	}
+
+	if o == outcomeIgnored {
+		return
+	}
+	failed := o == outcomeFailure
+	if b.count == len(b.window) {
+		if b.window[b.next] {
+			b.failures--
+		}
+	} else {
+		b.count++
+	}
+	b.window[b.next] = failed
+	b.next = (b.next + 1) % len(b.window)
+	if failed {
+		b.failures++
+	}
+	if b.count >= b.policy.MinRequests && float64(b.failures)/float64(b.count) >= b.policy.FailureRate {
+		changes = append(changes, b.setState(BreakerOpen))
+	}
+}
+
+// setState は状態を変化させ、各状態の計測をリセットします。呼び出し元が mu を保持している必要があります。
+func (b *CircuitBreaker) setState(to BreakerState) breakerTransition {
+	t := breakerTransition{from: b.state, to: to}
+	b.state = to
+	b.gen++
+	b.probes, b.successes = 0, 0
+	switch to {
+	case BreakerOpen:
+		b.openedAt = b.now()
+	case BreakerClosed:
+		clear(b.window)
+		b.next, b.count, b.failures = 0, 0, 0
+	}
+	return t
+}
+
+// notify は状態の変化を OnStateChange に通知します。mu を保持していない状態で呼び出します。
+func (b *CircuitBreaker) notify(changes []breakerTransition) {
+	if b.policy.OnStateChange == nil {
+		return
+	}
+	for _, t := range changes {
+		b.policy.OnStateChange(t.from, t.to)
+	}
+}
+
+// FallbackFunc は API を利用できない場合に代わりのレスポンスを返す関数です。
+// cause には ErrCircuitOpen や通信エラーなど、API を利用できなかった理由が渡されます。
+// キャッシュや vcr.Recorder の Do を使った再生など、[Client.SetDoFunc] と同じ形式でレスポンスを返します。
+type FallbackFunc func(req *http.Request, cause error) (*http.Response, error)
+
+// SetCircuitBreaker はリクエストに適用するサーキットブレーカーを設定します。
+// 通信エラー、5xx、429 のレスポンスを失敗として数えます。nil を指定すると無効になります。
+func (c *Client) SetCircuitBreaker(b *CircuitBreaker) {
+	c.breaker = b
+}
+
+// SetFallback は API を利用できない場合の代わりのレスポンスを返す関数を設定します。
+// サーキットブレーカーが開いている場合と、通信エラー、5xx、429 のレスポンスを受け取った場合に呼び出されます。
+// nil を指定すると無効になります。
+func (c *Client) SetFallback(f FallbackFunc) {
+	c.fallback = f
+}
+
+// upstreamFailure はレスポンスが API の障害によるものかを判定し、その理由を返します。障害でない場合は nil を返します。
+func upstreamFailure(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return NewError(resp.StatusCode, "unexpected status code")
+	}
+	return nil
+}
+
+// useFallback は FallbackFunc が設定されていればそのレスポンスを、設定されていなければ cause を返します。
+func (c *Client) useFallback(req *http.Request, cause error) (*http.Response, error) {
+	if c.fallback == nil {
+		return nil, cause
+	}
+	// 送信済みのリクエストボディを読み直せるようにする
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, cause
+		}
+		req.Body = body
+	}
+	return c.fallback(req, cause)
+}
@@ -0,0 +1,307 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock はテストから進められる時計です。
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// statusDoFunc は *status のステータスコードを返し、呼び出し回数を数える doFunc です。
+func statusDoFunc(status *int32, calls *int32) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+		return &http.Response{StatusCode: int(atomic.LoadInt32(status)), Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	}
+}
+
+// newBreakerClient は CircuitBreaker を設定したクライアントを返します。
+func newBreakerClient(policy yd4b.BreakerPolicy, status, calls *int32) (*yd4b.Client, *yd4b.CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	breaker := yd4b.NewCircuitBreaker(policy)
+	yd4b.SetBreakerClock(breaker, clock.Now)
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(statusDoFunc(status, calls))
+	client.SetCircuitBreaker(breaker)
+	return client, breaker, clock
+}
+
+func TestCircuitBreaker_Open(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int32
+		wantState yd4b.BreakerState
+	}{
+		{name: "server errors open", statuses: []int32{500, 502, 503, 504}, wantState: yd4b.BreakerOpen},
+		{name: "too many requests open", statuses: []int32{429, 429, 200, 200}, wantState: yd4b.BreakerOpen},
+		{name: "below failure rate", statuses: []int32{500, 200, 200, 200}, wantState: yd4b.BreakerClosed},
+		{name: "client errors are not failures", statuses: []int32{404, 400, 404, 400}, wantState: yd4b.BreakerClosed},
+		{name: "below min requests", statuses: []int32{500, 500, 500}, wantState: yd4b.BreakerClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var status, calls int32
+			var changes []string
+			client, breaker, _ := newBreakerClient(yd4b.BreakerPolicy{
+				MinRequests:   4,
+				FailureRate:   0.5,
+				OnStateChange: func(from, to yd4b.BreakerState) { changes = append(changes, from.String()+"->"+to.String()) },
+			}, &status, &calls)
+
+			for _, s := range tt.statuses {
+				atomic.StoreInt32(&status, s)
+				_, _ = client.Searchcode("1000001")
+			}
+			assert.Equal(t, tt.wantState, breaker.State())
+			if tt.wantState != yd4b.BreakerOpen {
+				assert.Empty(t, changes)
+				return
+			}
+			assert.Equal(t, []string{"closed->open"}, changes)
+
+			// 開いている間は送信せずに失敗する
+			_, err := client.Searchcode("1000001")
+			assert.ErrorIs(t, err, yd4b.ErrCircuitOpen)
+			var yerr *yd4b.Error
+			require.ErrorAs(t, err, &yerr)
+			assert.Equal(t, http.StatusServiceUnavailable, yerr.StatusCode)
+			assert.Equal(t, int32(len(tt.statuses)), atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name        string
+		probeStatus int32
+		wantState   yd4b.BreakerState
+		wantChanges []string
+	}{
+		{name: "probe succeeds", probeStatus: 200, wantState: yd4b.BreakerClosed, wantChanges: []string{"closed->open", "open->half-open", "half-open->closed"}},
+		{name: "probe fails", probeStatus: 500, wantState: yd4b.BreakerOpen, wantChanges: []string{"closed->open", "open->half-open", "half-open->open"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, calls := int32(500), int32(0)
+			var changes []string
+			client, breaker, clock := newBreakerClient(yd4b.BreakerPolicy{
+				MinRequests:   2,
+				OpenTimeout:   time.Minute,
+				OnStateChange: func(from, to yd4b.BreakerState) { changes = append(changes, from.String()+"->"+to.String()) },
+			}, &status, &calls)
+			for range 2 {
+				_, _ = client.Searchcode("1000001")
+			}
+			require.Equal(t, yd4b.BreakerOpen, breaker.State())
+
+			clock.Advance(time.Minute)
+			assert.Equal(t, yd4b.BreakerHalfOpen, breaker.State())
+			atomic.StoreInt32(&status, tt.probeStatus)
+			_, _ = client.Searchcode("1000001")
+			assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+			assert.Equal(t, tt.wantState, breaker.State())
+			assert.Equal(t, tt.wantChanges, changes)
+		})
+	}
+}
+
+func TestCircuitBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	t.Parallel()
+
+	status, calls := int32(500), int32(0)
+	client, breaker, clock := newBreakerClient(yd4b.BreakerPolicy{MinRequests: 1, OpenTimeout: time.Second}, &status, &calls)
+	_, _ = client.Searchcode("1000001")
+	require.Equal(t, yd4b.BreakerOpen, breaker.State())
+	clock.Advance(time.Second)
+
+	// 1件目のプローブが完了するまで、他のリクエストは送信しない
+	release := make(chan struct{})
+	started := make(chan struct{})
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		close(started)
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	})
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Searchcode("1000001")
+		errs <- err
+	}()
+	<-started
+	_, err := client.Searchcode("1000001")
+	assert.ErrorIs(t, err, yd4b.ErrCircuitOpen)
+
+	close(release)
+	require.NoError(t, <-errs)
+	assert.Equal(t, yd4b.BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreaker_HalfOpenProbeAfterThrottle(t *testing.T) {
+	t.Parallel()
+
+	status, calls := int32(500), int32(0)
+	client, breaker, clock := newBreakerClient(yd4b.BreakerPolicy{MinRequests: 1, OpenTimeout: time.Second}, &status, &calls)
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &http.Response{
+			StatusCode: int(atomic.LoadInt32(&status)),
+			Header:     http.Header{"Retry-After": {"60"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+		}, nil
+	})
+	client.SetAdaptiveThrottle(&yd4b.ThrottlePolicy{})
+	_, _ = client.Searchcode("1000001")
+	require.Equal(t, yd4b.BreakerOpen, breaker.State())
+	clock.Advance(time.Second)
+	atomic.StoreInt32(&status, http.StatusOK)
+
+	// レート制限で待機している間はプローブの枠を確保しないので、他のリクエストがプローブになれる
+	var sleeping atomic.Bool
+	var probeErr error
+	yd4b.SetSleep(client, func(ctx context.Context, d time.Duration) error {
+		if sleeping.CompareAndSwap(false, true) {
+			_, probeErr = client.Searchcode("1000001")
+		}
+		return nil
+	})
+	_, err := client.Searchcode("1000001")
+	require.NoError(t, probeErr)
+	require.NoError(t, err)
+	assert.Equal(t, yd4b.BreakerClosed, breaker.State())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestCircuitBreaker_Singleflight(t *testing.T) {
+	t.Parallel()
+
+	status, calls := int32(500), int32(0)
+	client, breaker, _ := newBreakerClient(yd4b.BreakerPolicy{MinRequests: 2}, &status, &calls)
+	client.SetSingleflight(true)
+	release := make(chan struct{})
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	})
+
+	// 集約された待機者の数ではなく、上流リクエスト1回分の失敗として記録する
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Searchcode("1000001")
+			assert.Error(t, err)
+		}()
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, yd4b.BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreaker_CanceledIsNotFailure(t *testing.T) {
+	t.Parallel()
+
+	breaker := yd4b.NewCircuitBreaker(yd4b.BreakerPolicy{MinRequests: 1})
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetCircuitBreaker(breaker)
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.SearchcodeContext(ctx, "1000001")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, yd4b.BreakerClosed, breaker.State())
+}
+
+func TestClient_Fallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		breaker   bool
+		status    int32
+		wantCause error
+	}{
+		{name: "circuit open", breaker: true, status: 500, wantCause: yd4b.ErrCircuitOpen},
+		{name: "server error", status: 503},
+		{name: "transport error", status: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, calls := tt.status, int32(0)
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				if tt.status < 0 {
+					return nil, errors.New("connection refused")
+				}
+				return statusDoFunc(&status, &calls)(req)
+			})
+			if tt.breaker {
+				breaker := yd4b.NewCircuitBreaker(yd4b.BreakerPolicy{MinRequests: 1})
+				client.SetCircuitBreaker(breaker)
+				_, _ = client.AddressZip(yd4b.WithPrefCode("13"))
+				require.Equal(t, yd4b.BreakerOpen, breaker.State())
+			}
+
+			var cause error
+			var body string
+			client.SetFallback(func(req *http.Request, c error) (*http.Response, error) {
+				cause = c
+				b, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				body = string(b)
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"count":1}`))}, nil
+			})
+
+			res, err := client.AddressZip(yd4b.WithPrefCode("13"))
+			require.NoError(t, err)
+			assert.Equal(t, 1, res.Count)
+			assert.Error(t, cause)
+			if tt.wantCause != nil {
+				assert.ErrorIs(t, cause, tt.wantCause)
+			}
+			assert.JSONEq(t, `{"pref_code":"13"}`, body, "fallback can read the request body")
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package yd4b
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Meta はデコード前のレスポンスの情報です。監査ログの記録やレスポンスヘッダの参照に使います。
+type Meta struct {
+	StatusCode int             // HTTPステータスコード
+	Header     http.Header     // レスポンスヘッダ
+	Body       []byte          // レスポンスボディ
+	Latency    time.Duration   // リクエストの送信からレスポンスボディの読み出しまでの時間
+	URL        string          // 最終的なリクエストURL（リダイレクトされた場合はリダイレクト先）
+	RateLimit  RateLimitStatus // レスポンスヘッダから読み取ったレート制限の状態
+}
+
+// endpoint はエンドポイントの呼び出し方を表す記述子です。
+// 新しいエンドポイントは記述子を定義して [call] に渡すだけで、他のエンドポイントと同じ処理を経由します。
+type endpoint[Req any] struct {
+	name   string                      // エンドポイントの名前（OpenAPI specification の operationId）
+	method string                      // HTTPメソッド
+	path   func(req Req) []string      // /api/{version}/ に続くパス要素
+	query  func(req Req, q url.Values) // クエリパラメータを設定する関数（nil の場合は設定しない）
+	body   bool                        // req を JSON にエンコードしてリクエストボディとして送信するかどうか
+	ecuid  bool                        // ECUID が設定されている場合に ec_uid クエリパラメータを付与するかどうか
+}
+
+// call は記述子に従ってリクエストを組み立てて送信し、レスポンスを Resp にデコードします。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - c: リクエストを送信するクライアント
+//   - ep: エンドポイントの記述子
+//   - req: リクエストの内容
+//
+// 戻り値:
+//   - Resp: デコードされたレスポンス
+//   - error: 通信エラー、ステータスコード異常、デコード失敗など
+func call[Req, Resp any](ctx context.Context, c *Client, ep endpoint[Req], req Req) (res Resp, err error) {
+	res, _, err = callWithMeta[Req, Resp](ctx, c, ep, req)
+	return
+}
+
+// callWithMeta は [call] と同じ処理を行い、レスポンスの情報も返します。
+// ステータスコードの異常やデコードの失敗でエラーを返す場合も、レスポンスを受信していれば Meta を返します。
+func callWithMeta[Req, Resp any](ctx context.Context, c *Client, ep endpoint[Req], req Req) (res Resp, meta Meta, err error) {
+	// エンドポイント組み立て
+	endpoint, err := url.JoinPath(c.origin, append([]string{"api", c.version}, ep.path(req)...)...)
+	if err != nil {
+		err = errors.Join(NewError(500, "endpoint error"), err)
+		return
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		err = errors.Join(NewError(500, "url parse error"), err)
+		return
+	}
+
+	// クエリパラメータ設定
+	q := u.Query()
+	if ep.ecuid && c.ecuid != "" {
+		q.Set("ec_uid", c.ecuid)
+	}
+	if ep.query != nil {
+		ep.query(req, q)
+	}
+	u.RawQuery = q.Encode()
+
+	// JSON エンコーディング
+	var body io.Reader
+	if ep.body {
+		jsonBody, jerr := json.Marshal(req)
+		if jerr != nil {
+			err = errors.Join(NewError(500, "json encoding error"), jerr)
+			return
+		}
+		body = bytes.NewBuffer(jsonBody)
+	}
+
+	// HTTP リクエスト生成
+	httpReq, err := http.NewRequestWithContext(ctx, ep.method, u.String(), body)
+	if err != nil {
+		err = errors.Join(NewError(500, "request creation error"), err)
+		return
+	}
+
+	// 実行
+	start := time.Now()
+	httpResp, err := c.do(httpReq)
+	if err != nil {
+		// サーキットブレーカーによる失敗は呼び出し元が区別できるようにそのまま返す
+		if !errors.Is(err, ErrCircuitOpen) {
+			err = errors.Join(NewError(500, "client do error"), err)
+		}
+		return
+	}
+	defer httpResp.Body.Close()
+
+	data, err := io.ReadAll(httpResp.Body)
+	meta = Meta{
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header,
+		Body:       data,
+		Latency:    time.Since(start),
+		URL:        httpReq.URL.String(),
+	}
+	meta.RateLimit, _ = parseRateLimit(httpResp.Header, time.Now())
+	if httpResp.Request != nil && httpResp.Request.URL != nil {
+		meta.URL = httpResp.Request.URL.String()
+	}
+
+	// ステータスコード確認
+	if httpResp.StatusCode != http.StatusOK {
+		err = NewError(httpResp.StatusCode, "unexpected status code")
+		return
+	}
+	if err != nil {
+		err = errors.Join(NewError(500, "response read error"), err)
+		return
+	}
+
+	// デコード
+	err = c.decodeResponse(ep.name, data, &res)
+	return
+}
@@ -0,0 +1,188 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall_UniformErrors(t *testing.T) {
+	endpoints := map[string]func(c *yd4b.Client) error{
+		"token":      func(c *yd4b.Client) error { _, err := c.GetToken(); return err },
+		"searchcode": func(c *yd4b.Client) error { _, err := c.Searchcode("1000001"); return err },
+		"addresszip": func(c *yd4b.Client) error { _, err := c.AddressZip(yd4b.WithPrefCode("13")); return err },
+	}
+	tests := []struct {
+		name          string
+		do            func(req *http.Request) (*http.Response, error)
+		wantStatus    int
+		wantErrSubstr string
+	}{
+		{
+			name:          "client do error",
+			do:            func(req *http.Request) (*http.Response, error) { return nil, errors.New("boom") },
+			wantStatus:    500,
+			wantErrSubstr: "client do error",
+		},
+		{
+			name: "unexpected status code",
+			do: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+			},
+			wantStatus:    http.StatusTooManyRequests,
+			wantErrSubstr: "unexpected status code",
+		},
+		{
+			name: "json decoding error",
+			do: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{`))}, nil
+			},
+			wantStatus:    500,
+			wantErrSubstr: "json decoding error",
+		},
+	}
+
+	for _, tt := range tests {
+		for name, call := range endpoints {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				t.Parallel()
+
+				client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+				client.SetDoFunc(tt.do)
+				err := call(client)
+				var yerr *yd4b.Error
+				require.ErrorAs(t, err, &yerr)
+				assert.Equal(t, tt.wantStatus, yerr.StatusCode)
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+			})
+		}
+	}
+}
+
+func TestCall_ECUID(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(c *yd4b.Client) error
+		wantQuery string
+	}{
+		{name: "token", call: func(c *yd4b.Client) error { _, err := c.GetToken(); return err }, wantQuery: ""},
+		{name: "searchcode", call: func(c *yd4b.Client) error { _, err := c.Searchcode("1000001", yd4b.WithSCPage(2)); return err }, wantQuery: "ec_uid=provider&page=2"},
+		{name: "addresszip", call: func(c *yd4b.Client) error { _, err := c.AddressZip(); return err }, wantQuery: "ec_uid=provider"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var query string
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetECUID("provider")
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				query = req.URL.RawQuery
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+			})
+			require.NoError(t, tt.call(client))
+			assert.Equal(t, tt.wantQuery, query)
+		})
+	}
+}
+
+func TestClient_WithMeta(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		call       func(c *yd4b.Client) (yd4b.Meta, error)
+		wantStatus int
+		wantErr    bool
+		wantURL    string
+	}{
+		{
+			name:   "searchcode",
+			status: http.StatusOK,
+			body:   `{"count":3}`,
+			call: func(c *yd4b.Client) (yd4b.Meta, error) {
+				res, meta, err := c.SearchcodeWithMeta(context.Background(), "1000001", yd4b.WithSCLimit(5))
+				assert.Equal(t, 3, res.Count)
+				return meta, err
+			},
+			wantStatus: http.StatusOK,
+			wantURL:    "https://api.example.com/api/v1/searchcode/1000001?limit=5",
+		},
+		{
+			name:   "addresszip",
+			status: http.StatusOK,
+			body:   `{"count":2}`,
+			call: func(c *yd4b.Client) (yd4b.Meta, error) {
+				res, meta, err := c.AddressZipWithMeta(context.Background(), yd4b.WithPrefCode("13"))
+				assert.Equal(t, 2, res.Count)
+				return meta, err
+			},
+			wantStatus: http.StatusOK,
+			wantURL:    "https://api.example.com/api/v1/addresszip",
+		},
+		{
+			name:   "meta on error status",
+			status: http.StatusBadRequest,
+			body:   `{"message":"invalid code"}`,
+			call: func(c *yd4b.Client) (yd4b.Meta, error) {
+				_, meta, err := c.SearchcodeWithMeta(context.Background(), "x")
+				return meta, err
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+			wantURL:    "https://api.example.com/api/v1/searchcode/x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				time.Sleep(time.Millisecond)
+				return &http.Response{
+					StatusCode: tt.status,
+					Header:     http.Header{"X-Request-Id": {"req-1"}},
+					Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+				}, nil
+			})
+
+			meta, err := tt.call(client)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantStatus, meta.StatusCode)
+			assert.Equal(t, "req-1", meta.Header.Get("X-Request-Id"))
+			assert.Equal(t, tt.body, string(meta.Body))
+			assert.Equal(t, tt.wantURL, meta.URL)
+			assert.GreaterOrEqual(t, meta.Latency, time.Millisecond)
+		})
+	}
+}
+
+func TestClient_WithMeta_FinalURL(t *testing.T) {
+	t.Parallel()
+
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		redirected, err := http.NewRequest(http.MethodGet, "https://api2.example.com/api/v1/searchcode/1000001", nil)
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`)), Request: redirected}, nil
+	})
+
+	_, meta, err := client.SearchcodeWithMeta(context.Background(), "1000001")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api2.example.com/api/v1/searchcode/1000001", meta.URL)
+}
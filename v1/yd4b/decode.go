@@ -0,0 +1,142 @@
+package yd4b
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// DecodeMode はレスポンスに未知のフィールドが含まれていた場合の扱いです。
+type DecodeMode int
+
+const (
+	DecodeLenient DecodeMode = iota // 未知のフィールドを無視する（既定）
+	DecodeWarn                      // 未知のフィールドを UnknownFieldHook に通知する
+	DecodeStrict                    // 未知のフィールドを UnknownFieldHook に通知し、エラーを返す
+)
+
+// ErrUnknownField は [DecodeStrict] でレスポンスに未知のフィールドが含まれていた場合のエラーです。
+var ErrUnknownField = NewError(http.StatusInternalServerError, "unknown json field")
+
+// UnknownFieldHook はレスポンスに未知のフィールドが含まれていた場合に呼び出される関数です。
+// endpoint は "token"、"searchcode"、"addresszip" のいずれか、fields は "addresses[].new_field" の形式のフィールドの一覧です。
+type UnknownFieldHook func(endpoint string, fields []string)
+
+// SetDecodeMode はレスポンスに未知のフィールドが含まれていた場合の扱いを設定します。
+// API の仕様変更でフィールドが追加・改名されたことを検出する用途を想定しています。
+func (c *Client) SetDecodeMode(mode DecodeMode) {
+	c.decodeMode = mode
+}
+
+// SetUnknownFieldHook は未知のフィールドを通知する関数を設定します。
+// [DecodeWarn] または [DecodeStrict] の場合に呼び出されます。
+func (c *Client) SetUnknownFieldHook(hook UnknownFieldHook) {
+	c.unknownFieldHook = hook
+}
+
+// decodeResponse はレスポンスを res にデコードし、DecodeMode に従って未知のフィールドを報告します。
+func (c *Client) decodeResponse(name string, data []byte, res any) error {
+	if err := json.Unmarshal(data, res); err != nil {
+		return errors.Join(NewError(500, "json decoding error"), err)
+	}
+	if c.decodeMode == DecodeLenient {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.Join(NewError(500, "json decoding error"), err)
+	}
+	seen := map[string]bool{}
+	unknownFields(v, reflect.TypeOf(res), "", seen)
+	if len(seen) == 0 {
+		return nil
+	}
+	fields := slices.Sorted(maps.Keys(seen))
+	if c.unknownFieldHook != nil {
+		c.unknownFieldHook(name, fields)
+	}
+	if c.decodeMode == DecodeStrict {
+		return errors.Join(ErrUnknownField, errors.New(name+": "+strings.Join(fields, ", ")))
+	}
+	return nil
+}
+
+// unknownFields は JSON の値 v を型 t と照らし合わせ、t に対応するフィールドのないキーを seen に追加します。
+func unknownFields(v any, t reflect.Type, path string, seen map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, child := range obj {
+			f, ok := jsonField(t, key)
+			if !ok {
+				seen[path+key] = true
+				continue
+			}
+			unknownFields(child, f.Type, path+key+".", seen)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]any)
+		if !ok {
+			return
+		}
+		for _, child := range arr {
+			unknownFields(child, t.Elem(), strings.TrimSuffix(path, ".")+"[].", seen)
+		}
+	}
+}
+
+// jsonField は encoding/json と同じ規則で、JSON のキーに対応する構造体のフィールドを返します。
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var folded *reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if name == key {
+			return f, true
+		}
+		if folded == nil && strings.EqualFold(name, key) {
+			folded = &f
+		}
+	}
+	if folded != nil {
+		return *folded, true
+	}
+	return reflect.StructField{}, false
+}
+
+// extraFields は data のうち known に含まれないキーを返します。未知のキーがない場合は nil を返します。
+func extraFields(data []byte, known reflect.Type) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for key := range all {
+		if _, ok := jsonField(known, key); ok {
+			delete(all, key)
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
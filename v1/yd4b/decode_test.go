@@ -0,0 +1,118 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// driftedSearchcode は仕様にないフィールドを含む searchcode のレスポンスです。
+const driftedSearchcode = `{
+	"page": 1, "limit": 10, "count": 1, "searchtype": "zipcode", "request_id": "abc",
+	"addresses": [
+		{"zip_code": "1000001", "pref_name": "東京都", "dgacode": null, "corp_number": "1234", "geo": {"lat": 35.6}},
+		{"zip_code": "1000002", "pref_name": "東京都"}
+	]
+}`
+
+func TestClient_DecodeMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       yd4b.DecodeMode
+		body       string
+		wantFields []string
+		wantErr    bool
+	}{
+		{name: "lenient ignores unknown fields", mode: yd4b.DecodeLenient, body: driftedSearchcode},
+		{name: "warn reports unknown fields", mode: yd4b.DecodeWarn, body: driftedSearchcode, wantFields: []string{"addresses[].corp_number", "addresses[].geo", "request_id"}},
+		{name: "strict returns error", mode: yd4b.DecodeStrict, body: driftedSearchcode, wantFields: []string{"addresses[].corp_number", "addresses[].geo", "request_id"}, wantErr: true},
+		{name: "strict accepts known fields", mode: yd4b.DecodeStrict, body: `{"page":1,"addresses":[{"zip_code":"1000001","latitude":35.6}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDecodeMode(tt.mode)
+			var gotEndpoint string
+			var gotFields []string
+			client.SetUnknownFieldHook(func(endpoint string, fields []string) {
+				gotEndpoint, gotFields = endpoint, fields
+			})
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(tt.body))}, nil
+			})
+
+			res, err := client.Searchcode("1000001")
+			assert.Equal(t, tt.wantFields, gotFields)
+			if tt.wantFields != nil {
+				assert.Equal(t, "searchcode", gotEndpoint)
+			}
+			if tt.wantErr {
+				assert.ErrorIs(t, err, yd4b.ErrUnknownField)
+				assert.ErrorContains(t, err, "searchcode: addresses[].corp_number, addresses[].geo, request_id")
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, res.Addresses)
+		})
+	}
+}
+
+func TestSearchcodeAddressItem_Extras(t *testing.T) {
+	t.Parallel()
+
+	var res yd4b.SearchcodeResponse
+	require.NoError(t, json.Unmarshal([]byte(driftedSearchcode), &res))
+	require.Len(t, res.Addresses, 2)
+
+	item := res.Addresses[0]
+	assert.Equal(t, "1000001", item.ZipCode)
+	assert.Nil(t, item.DgaCode)
+	assert.Equal(t, map[string]json.RawMessage{
+		"corp_number": json.RawMessage(`"1234"`),
+		"geo":         json.RawMessage(`{"lat": 35.6}`),
+	}, item.Extras)
+	assert.Nil(t, res.Addresses[1].Extras, "no extras when every field is known")
+
+	// Extras は JSON に出力しない
+	b, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "corp_number")
+}
+
+func TestAddressItem_Extras(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want yd4b.AddressItem
+	}{
+		{
+			name: "known fields only",
+			body: `{"zip_code":"1000001","town_name":"千代田"}`,
+			want: yd4b.AddressItem{ZipCode: "1000001", TownName: "千代田"},
+		},
+		{
+			name: "unknown field",
+			body: `{"zip_code":"1000001","town_name_old":"旧町名"}`,
+			want: yd4b.AddressItem{ZipCode: "1000001", Extras: map[string]json.RawMessage{"town_name_old": json.RawMessage(`"旧町名"`)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got yd4b.AddressItem
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package yd4b
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// DefaultIPResolverURL は [NewHTTPIPResolver] に渡すことを想定した、送信元IPアドレスをテキストで返すサービスのURLです。
+const DefaultIPResolverURL = "https://api.ipify.org"
+
+// IPResolver はクライアントの送信元グローバルIPアドレスを検出するインターフェースです。
+type IPResolver interface {
+	// ResolveIP は送信元グローバルIPアドレスを返します。
+	ResolveIP(ctx context.Context) (string, error)
+}
+
+// IPResolverFunc は関数を [IPResolver] として扱うための型です。
+type IPResolverFunc func(ctx context.Context) (string, error)
+
+// ResolveIP は f を呼び出します。
+func (f IPResolverFunc) ResolveIP(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// HTTPIPResolver は送信元IPアドレスをテキストで返すHTTPサービスを使う [IPResolver] です。
+type HTTPIPResolver struct {
+	url    string
+	doFunc func(req *http.Request) (*http.Response, error)
+}
+
+// NewHTTPIPResolver は url に GET リクエストを送信して送信元IPアドレスを検出する HTTPIPResolver を生成します。
+//
+// 引数:
+//   - url: レスポンスボディに送信元IPアドレスだけを返すサービスのURL（例: [DefaultIPResolverURL]）
+//
+// 戻り値:
+//   - *HTTPIPResolver: 生成された HTTPIPResolver
+func NewHTTPIPResolver(url string) *HTTPIPResolver {
+	return &HTTPIPResolver{url: url, doFunc: http.DefaultClient.Do}
+}
+
+// SetDoFunc はリクエストに使う関数を書き換えます。
+// API と同じ経路から送信されるよう、クライアントと同じ HTTP クライアントを使ってください。
+func (r *HTTPIPResolver) SetDoFunc(do func(req *http.Request) (*http.Response, error)) {
+	r.doFunc = do
+}
+
+// ResolveIP はサービスに問い合わせて送信元IPアドレスを返します。
+func (r *HTTPIPResolver) ResolveIP(ctx context.Context) (ip string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		err = errors.Join(NewError(500, "request creation error"), err)
+		return
+	}
+	resp, err := r.doFunc(req)
+	if err != nil {
+		err = errors.Join(NewError(500, "client do error"), err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = NewError(resp.StatusCode, "unexpected status code")
+		return
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		err = errors.Join(NewError(500, "response read error"), err)
+		return
+	}
+	ip = strings.TrimSpace(string(b))
+	return
+}
+
+// IPSource は x-forwarded-for に設定するIPアドレスの取得元です。
+type IPSource string
+
+const (
+	IPSourceStatic   IPSource = "static"   // NewClient で指定されたIPアドレス
+	IPSourceResolver IPSource = "resolver" // IPResolver で検出したIPアドレス
+)
+
+// IPDiagnosis は [Client.DiagnoseIP] の結果です。
+type IPDiagnosis struct {
+	Sent     string   // x-forwarded-for ヘッダに設定されるIPアドレス
+	Source   IPSource // Sent の取得元
+	Detected string   // IPResolver で検出した送信元IPアドレス（IPResolver が未設定の場合は空文字列）
+	Match    bool     // Sent と Detected が一致するかどうか（Detected が空の場合は false）
+}
+
+// validateIP はIPv4またはIPv6アドレスとして正しいかを検証し、正規化した文字列を返します。
+func validateIP(ip string) (string, error) {
+	if ip == "" {
+		return "", NewError(500, "myip is not set: pass the registered global IP address to NewClient or call SetIPResolver")
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || addr.Zone() != "" {
+		return "", errors.Join(NewError(500, "myip is not a valid IPv4 or IPv6 address: "+ip), err)
+	}
+	return addr.Unmap().String(), nil
+}
+
+// SetIPResolver は送信元IPアドレスの検出方法を設定します。
+// NewClient に渡した myip が空の場合、最初のリクエストの前に検出したIPアドレスを x-forwarded-for ヘッダに設定します。
+// 検出結果はキャッシュされ、この関数を再度呼び出すとクリアされます。nil を指定すると無効になります。
+func (c *Client) SetIPResolver(r IPResolver) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ipResolver = r
+	c.resolvedIP = ""
+	c.ipResolverGen++
+}
+
+// forwardedIP は x-forwarded-for ヘッダに設定するIPアドレスとその取得元を返します。
+// myip が空で IPResolver が設定されている場合は検出したIPアドレスを返します。
+func (c *Client) forwardedIP(ctx context.Context) (string, IPSource, error) {
+	if c.myip != "" {
+		ip, err := validateIP(c.myip)
+		return ip, IPSourceStatic, err
+	}
+
+	c.mu.RLock()
+	resolver, ip, gen := c.ipResolver, c.resolvedIP, c.ipResolverGen
+	c.mu.RUnlock()
+	if resolver == nil {
+		_, err := validateIP("")
+		return "", IPSourceStatic, err
+	}
+	if ip != "" {
+		return ip, IPSourceResolver, nil
+	}
+
+	ip, err := c.resolveIP(ctx, resolver)
+	if err != nil {
+		return "", IPSourceResolver, err
+	}
+	c.mu.Lock()
+	// 検出中に SetIPResolver が呼ばれた場合は古い結果をキャッシュしない
+	if c.ipResolverGen == gen {
+		c.resolvedIP = ip
+	}
+	c.mu.Unlock()
+	return ip, IPSourceResolver, nil
+}
+
+// resolveIP は resolver で送信元IPアドレスを検出し、検証します。
+func (c *Client) resolveIP(ctx context.Context, resolver IPResolver) (string, error) {
+	ip, err := resolver.ResolveIP(ctx)
+	if err != nil {
+		return "", errors.Join(NewError(500, "ip resolution error"), err)
+	}
+	return validateIP(strings.TrimSpace(ip))
+}
+
+// DiagnoseIP は x-forwarded-for ヘッダに設定されるIPアドレスを報告します。
+// IPResolver が設定されている場合は送信元IPアドレスを検出し直し、設定されたIPアドレスと一致するかを確認します。
+// 登録済みのIPアドレスと異なる値が送信されていないかを確認する用途を想定しています。
+//
+// 引数:
+//   - ctx: コンテキスト
+//
+// 戻り値:
+//   - IPDiagnosis: 送信されるIPアドレスと検出結果
+//   - error: 送信されるIPアドレスが不正な場合や、検出に失敗した場合のエラー
+func (c *Client) DiagnoseIP(ctx context.Context) (d IPDiagnosis, err error) {
+	if d.Sent, d.Source, err = c.forwardedIP(ctx); err != nil {
+		return
+	}
+
+	c.mu.RLock()
+	resolver := c.ipResolver
+	c.mu.RUnlock()
+	if resolver == nil {
+		return
+	}
+	if d.Detected, err = c.resolveIP(ctx, resolver); err != nil {
+		return
+	}
+	d.Match = d.Sent == d.Detected
+	return
+}
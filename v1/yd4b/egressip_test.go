@@ -0,0 +1,146 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticResolver は固定のIPアドレスを返し、呼び出し回数を数える IPResolver を返します。
+func staticResolver(ip string, calls *int32) yd4b.IPResolver {
+	return yd4b.IPResolverFunc(func(ctx context.Context) (string, error) {
+		atomic.AddInt32(calls, 1)
+		return ip, nil
+	})
+}
+
+func TestClient_ForwardedIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		myip          string
+		resolver      func(calls *int32) yd4b.IPResolver
+		wantHeader    string
+		wantErrSubstr string
+	}{
+		{name: "static ipv4", myip: "1.2.3.4", wantHeader: "1.2.3.4"},
+		{name: "static ipv6", myip: "2001:DB8::1", wantHeader: "2001:db8::1"},
+		{name: "static wins over resolver", myip: "1.2.3.4", resolver: func(c *int32) yd4b.IPResolver { return staticResolver("5.6.7.8", c) }, wantHeader: "1.2.3.4"},
+		{name: "resolved", resolver: func(c *int32) yd4b.IPResolver { return staticResolver(" 5.6.7.8\n", c) }, wantHeader: "5.6.7.8"},
+		{name: "not set", wantErrSubstr: "myip is not set"},
+		{name: "invalid static", myip: "1.2.3", wantErrSubstr: "not a valid IPv4 or IPv6 address"},
+		{name: "invalid resolved", resolver: func(c *int32) yd4b.IPResolver { return staticResolver("<html>", c) }, wantErrSubstr: "not a valid IPv4 or IPv6 address"},
+		{
+			name: "resolver error",
+			resolver: func(c *int32) yd4b.IPResolver {
+				return yd4b.IPResolverFunc(func(ctx context.Context) (string, error) { return "", errors.New("boom") })
+			},
+			wantErrSubstr: "ip resolution error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var resolves, requests int32
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", tt.myip)
+			if tt.resolver != nil {
+				client.SetIPResolver(tt.resolver(&resolves))
+			}
+			var header string
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&requests, 1)
+				header = req.Header.Get("x-forwarded-for")
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+			})
+
+			for range 2 {
+				_, err := client.Searchcode("1000001")
+				if tt.wantErrSubstr != "" {
+					assert.ErrorContains(t, err, tt.wantErrSubstr)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantHeader, header)
+			}
+			if tt.wantErrSubstr != "" {
+				// 不正な場合はリクエストを送信しない
+				assert.Zero(t, atomic.LoadInt32(&requests))
+			}
+			if tt.myip == "" && tt.wantErrSubstr == "" {
+				// 検出結果はキャッシュされる
+				assert.Equal(t, int32(1), atomic.LoadInt32(&resolves))
+			}
+		})
+	}
+}
+
+func TestClient_DiagnoseIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		myip     string
+		detected string
+		want     yd4b.IPDiagnosis
+	}{
+		{name: "static only", myip: "1.2.3.4", want: yd4b.IPDiagnosis{Sent: "1.2.3.4", Source: yd4b.IPSourceStatic}},
+		{name: "static match", myip: "1.2.3.4", detected: "1.2.3.4", want: yd4b.IPDiagnosis{Sent: "1.2.3.4", Source: yd4b.IPSourceStatic, Detected: "1.2.3.4", Match: true}},
+		{name: "static mismatch", myip: "1.2.3.4", detected: "5.6.7.8", want: yd4b.IPDiagnosis{Sent: "1.2.3.4", Source: yd4b.IPSourceStatic, Detected: "5.6.7.8"}},
+		{name: "resolved", detected: "5.6.7.8", want: yd4b.IPDiagnosis{Sent: "5.6.7.8", Source: yd4b.IPSourceResolver, Detected: "5.6.7.8", Match: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", tt.myip)
+			if tt.detected != "" {
+				client.SetIPResolver(staticResolver(tt.detected, &calls))
+			}
+			got, err := client.DiagnoseIP(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPIPResolver(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		want          string
+		wantErrSubstr string
+	}{
+		{name: "ok", status: http.StatusOK, body: "203.0.113.1\n", want: "203.0.113.1"},
+		{name: "bad status", status: http.StatusServiceUnavailable, wantErrSubstr: "unexpected status code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := yd4b.NewHTTPIPResolver("https://ip.example.com")
+			r.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "https://ip.example.com", req.URL.String())
+				assert.Empty(t, req.Header.Get("x-forwarded-for"))
+				return &http.Response{StatusCode: tt.status, Body: io.NopCloser(bytes.NewBufferString(tt.body))}, nil
+			})
+			got, err := r.ResolveIP(context.Background())
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package yd4b
 
 import (
+	"context"
 	"net/http"
+	"time"
 )
 
 func Do(c *Client, req *http.Request) (*http.Response, error) {
@@ -22,6 +24,8 @@ func GetOrigin(c *Client) string {
 
 var NewSearchcodeRequest = newSearchcodeRequest
 
+var NewClientFromEnvFunc = newClientFromEnv
+
 type AddressRequest = addressRequest
 type AddressRequestOption = addressRequestOption
 type SearchcodeOption = searchcodeOption
@@ -29,3 +33,11 @@ type SearchcodeOption = searchcodeOption
 func NewAddressRequest(opts ...addressRequestOption) addressRequest {
 	return newAddressRequest(opts...)
 }
+
+func SetSleep(c *Client, sleep func(ctx context.Context, d time.Duration) error) {
+	c.sleep = sleep
+}
+
+func SetBreakerClock(b *CircuitBreaker, now func() time.Time) {
+	b.now = now
+}
@@ -0,0 +1,124 @@
+package yd4b
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// earthRadius は距離計算に用いる地球の平均半径（メートル）です。
+const earthRadius = 6371008.8
+
+// Coordinate は緯度・経度の組を表す構造体です。
+type Coordinate struct {
+	Latitude  float64 `json:"latitude"`  // 緯度
+	Longitude float64 `json:"longitude"` // 経度
+}
+
+// Coordinate は住所アイテムの緯度・経度を返します。
+// 緯度・経度のいずれかが nil の場合は false を返します。
+func (a SearchcodeAddressItem) Coordinate() (Coordinate, bool) {
+	if a.Latitude == nil || a.Longitude == nil {
+		return Coordinate{}, false
+	}
+	return Coordinate{Latitude: *a.Latitude, Longitude: *a.Longitude}, true
+}
+
+// Distance は2地点間の距離をハバーサイン公式で計算し、メートル単位で返します。
+func Distance(a, b Coordinate) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := (b.Latitude - a.Latitude) * math.Pi / 180
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// DistanceBetween は2つの住所アイテム間の距離をメートル単位で返します。
+// どちらかの緯度・経度が nil の場合は false を返します。
+func DistanceBetween(a, b SearchcodeAddressItem) (float64, bool) {
+	ca, ok := a.Coordinate()
+	if !ok {
+		return 0, false
+	}
+	cb, ok := b.Coordinate()
+	if !ok {
+		return 0, false
+	}
+	return Distance(ca, cb), true
+}
+
+// SortByDistance は住所アイテムを指定地点から近い順に並べ替えます。
+// 緯度・経度を持たないアイテムは末尾に元の順序のまま配置されます。
+func SortByDistance(items []SearchcodeAddressItem, from Coordinate) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ci, okI := items[i].Coordinate()
+		cj, okJ := items[j].Coordinate()
+		if !okI || !okJ {
+			return okI && !okJ
+		}
+		return Distance(from, ci) < Distance(from, cj)
+	})
+}
+
+// GeoIndex は取得済みのコード番号検索結果から構築する、最近傍検索用のローカルインデックスです。
+// 複数のゴルーチンから安全に利用できます。
+type GeoIndex struct {
+	mu    sync.RWMutex
+	items []SearchcodeAddressItem
+}
+
+// NewGeoIndex は空の [GeoIndex] を生成します。
+func NewGeoIndex() *GeoIndex {
+	return &GeoIndex{}
+}
+
+// Add はコード番号検索のレスポンスに含まれる住所アイテムをインデックスに追加します。
+// 緯度・経度を持たないアイテムは無視されます。
+func (g *GeoIndex) Add(responses ...SearchcodeResponse) {
+	for _, res := range responses {
+		g.AddItems(res.Addresses...)
+	}
+}
+
+// AddItems は住所アイテムをインデックスに追加します。
+// 緯度・経度を持たないアイテムは無視されます。
+func (g *GeoIndex) AddItems(items ...SearchcodeAddressItem) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, item := range items {
+		if _, ok := item.Coordinate(); ok {
+			g.items = append(g.items, item)
+		}
+	}
+}
+
+// Len はインデックスに登録されている住所アイテムの件数を返します。
+func (g *GeoIndex) Len() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.items)
+}
+
+// Nearest は指定地点に最も近い住所アイテムを返します。
+//
+// 引数:
+//   - c: 基準となる緯度・経度
+//
+// 戻り値:
+//   - SearchcodeAddressItem: 最も近い住所アイテム
+//   - float64: 基準地点からの距離（メートル）
+//   - bool: インデックスが空の場合は false
+func (g *GeoIndex) Nearest(c Coordinate) (item SearchcodeAddressItem, distance float64, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, candidate := range g.items {
+		cc, _ := candidate.Coordinate()
+		d := Distance(c, cc)
+		if !ok || d < distance {
+			item, distance, ok = candidate, d, true
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package yd4b_test
+
+import (
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+func geoItem(zip string, lat, lon float64) yd4b.SearchcodeAddressItem {
+	return yd4b.SearchcodeAddressItem{ZipCode: zip, Latitude: &lat, Longitude: &lon}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  yd4b.Coordinate
+		want  float64
+		delta float64
+	}{
+		{
+			name:  "same point",
+			a:     yd4b.Coordinate{Latitude: 35.68, Longitude: 139.76},
+			b:     yd4b.Coordinate{Latitude: 35.68, Longitude: 139.76},
+			want:  0,
+			delta: 0.001,
+		},
+		{
+			name:  "tokyo to osaka",
+			a:     yd4b.Coordinate{Latitude: 35.681236, Longitude: 139.767125},
+			b:     yd4b.Coordinate{Latitude: 34.702485, Longitude: 135.495951},
+			want:  403000,
+			delta: 2000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, yd4b.Distance(tt.a, tt.b), tt.delta)
+		})
+	}
+}
+
+func TestDistanceBetween(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   yd4b.SearchcodeAddressItem
+		wantOK bool
+	}{
+		{
+			name:   "both have coordinates",
+			a:      geoItem("1000001", 35.68, 139.75),
+			b:      geoItem("1000005", 35.68, 139.76),
+			wantOK: true,
+		},
+		{
+			name:   "nil coordinates",
+			a:      geoItem("1000001", 35.68, 139.75),
+			b:      yd4b.SearchcodeAddressItem{ZipCode: "1000005"},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d, ok := yd4b.DistanceBetween(tt.a, tt.b)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Greater(t, d, 0.0)
+			}
+		})
+	}
+}
+
+func TestSortByDistance(t *testing.T) {
+	t.Parallel()
+
+	items := []yd4b.SearchcodeAddressItem{
+		{ZipCode: "nil"},
+		geoItem("far", 34.70, 135.49),
+		geoItem("near", 35.68, 139.76),
+		geoItem("middle", 35.17, 136.88),
+	}
+	yd4b.SortByDistance(items, yd4b.Coordinate{Latitude: 35.68, Longitude: 139.76})
+
+	got := make([]string, 0, len(items))
+	for _, item := range items {
+		got = append(got, item.ZipCode)
+	}
+	assert.Equal(t, []string{"near", "middle", "far", "nil"}, got)
+}
+
+func TestGeoIndex_Nearest(t *testing.T) {
+	t.Parallel()
+
+	idx := yd4b.NewGeoIndex()
+	_, _, ok := idx.Nearest(yd4b.Coordinate{})
+	assert.False(t, ok)
+
+	idx.Add(yd4b.SearchcodeResponse{Addresses: []yd4b.SearchcodeAddressItem{
+		geoItem("1000001", 35.6850, 139.7528),
+		geoItem("5300001", 34.7025, 135.4960),
+		{ZipCode: "0000000"},
+	}})
+	idx.AddItems(geoItem("4500002", 35.1709, 136.8815))
+	assert.Equal(t, 3, idx.Len())
+
+	item, d, ok := idx.Nearest(yd4b.Coordinate{Latitude: 34.70, Longitude: 135.50})
+	assert.True(t, ok)
+	assert.Equal(t, "5300001", item.ZipCode)
+	assert.Less(t, d, 1000.0)
+}
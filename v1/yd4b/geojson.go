@@ -0,0 +1,192 @@
+package yd4b
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// GeoJSONFeatureCollection は GeoJSON の FeatureCollection を表す構造体です。
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`     // 常に "FeatureCollection"
+	Features []GeoJSONFeature `json:"features"` // Feature の一覧
+}
+
+// GeoJSONFeature は GeoJSON の Feature を表す構造体です。
+type GeoJSONFeature struct {
+	Type       string          `json:"type"`       // 常に "Feature"
+	Geometry   GeoJSONGeometry `json:"geometry"`   // ジオメトリ（Point）
+	Properties map[string]any  `json:"properties"` // 住所情報のプロパティ
+}
+
+// GeoJSONGeometry は GeoJSON の Point ジオメトリを表す構造体です。
+type GeoJSONGeometry struct {
+	Type        string    `json:"type"`        // 常に "Point"
+	Coordinates []float64 `json:"coordinates"` // [経度, 緯度] の順
+}
+
+// ToGeoJSONFeature は住所アイテムを GeoJSON の Feature に変換します。
+// 緯度・経度のいずれかが nil の場合は false を返します。
+func (a SearchcodeAddressItem) ToGeoJSONFeature() (GeoJSONFeature, bool) {
+	c, ok := a.Coordinate()
+	if !ok {
+		return GeoJSONFeature{}, false
+	}
+
+	props := map[string]any{
+		"zip_code":  a.ZipCode,
+		"pref_code": a.PrefCode,
+		"pref_name": a.PrefName,
+		"city_code": a.CityCode,
+		"city_name": a.CityName,
+		"town_name": a.TownName,
+	}
+	optional := map[string]*string{
+		"dgacode":    a.DgaCode,
+		"pref_kana":  a.PrefKana,
+		"pref_roma":  a.PrefRoma,
+		"city_kana":  a.CityKana,
+		"city_roma":  a.CityRoma,
+		"town_kana":  a.TownKana,
+		"town_roma":  a.TownRoma,
+		"biz_name":   a.BizName,
+		"biz_kana":   a.BizKana,
+		"biz_roma":   a.BizRoma,
+		"block_name": a.BlockName,
+		"other_name": a.OtherName,
+		"address":    a.Address,
+	}
+	for k, v := range optional {
+		if v != nil {
+			props[k] = *v
+		}
+	}
+
+	return GeoJSONFeature{
+		Type: "Feature",
+		Geometry: GeoJSONGeometry{
+			Type:        "Point",
+			Coordinates: []float64{c.Longitude, c.Latitude},
+		},
+		Properties: props,
+	}, true
+}
+
+// NewGeoJSONFeatureCollection は複数のコード番号検索結果をまとめて GeoJSON の FeatureCollection に変換します。
+//
+// 引数:
+//   - responses: 変換するコード番号検索結果
+//
+// 戻り値:
+//   - GeoJSONFeatureCollection: 変換結果
+//   - []SearchcodeAddressItem: 緯度・経度が nil のため変換できなかった住所アイテム
+func NewGeoJSONFeatureCollection(responses ...SearchcodeResponse) (fc GeoJSONFeatureCollection, skipped []SearchcodeAddressItem) {
+	fc = GeoJSONFeatureCollection{Type: "FeatureCollection", Features: []GeoJSONFeature{}}
+	for _, res := range responses {
+		for _, item := range res.Addresses {
+			f, ok := item.ToGeoJSONFeature()
+			if !ok {
+				skipped = append(skipped, item)
+				continue
+			}
+			fc.Features = append(fc.Features, f)
+		}
+	}
+	return
+}
+
+// ToGeoJSON はコード番号検索結果を GeoJSON の FeatureCollection に変換します。
+// 緯度・経度が nil のため変換できなかった住所アイテムは skipped として返します。
+func (r SearchcodeResponse) ToGeoJSON() (fc GeoJSONFeatureCollection, skipped []SearchcodeAddressItem) {
+	return NewGeoJSONFeatureCollection(r)
+}
+
+// GeoJSONEncoder は大量の検索結果を FeatureCollection として逐次書き出すエンコーダです。
+// 全件をメモリに保持せずに出力できます。書き込み終了後は必ず [GeoJSONEncoder.Close] を呼び出してください。
+type GeoJSONEncoder struct {
+	w       io.Writer
+	started bool
+	closed  bool
+	count   int
+	skipped int
+}
+
+// NewGeoJSONEncoder は w に書き出す [GeoJSONEncoder] を生成します。
+func NewGeoJSONEncoder(w io.Writer) *GeoJSONEncoder {
+	return &GeoJSONEncoder{w: w}
+}
+
+// Encode は住所アイテムを Feature として書き出します。
+// 緯度・経度が nil のアイテムは書き出さずに false を返します。
+func (e *GeoJSONEncoder) Encode(item SearchcodeAddressItem) (bool, error) {
+	if e.closed {
+		return false, NewError(500, "geojson encoder already closed")
+	}
+	f, ok := item.ToGeoJSONFeature()
+	if !ok {
+		e.skipped++
+		return false, nil
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		return false, errors.Join(NewError(500, "json encoding error"), err)
+	}
+
+	prefix := ","
+	if !e.started {
+		prefix = `{"type":"FeatureCollection","features":[`
+	}
+	if _, err := io.WriteString(e.w, prefix); err != nil {
+		return false, errors.Join(NewError(500, "write error"), err)
+	}
+	e.started = true
+	if _, err := e.w.Write(b); err != nil {
+		return false, errors.Join(NewError(500, "write error"), err)
+	}
+	e.count++
+	return true, nil
+}
+
+// EncodeResponse はコード番号検索結果に含まれる住所アイテムをすべて書き出します。
+// 戻り値は緯度・経度が nil のため書き出さなかった住所アイテムです。
+func (e *GeoJSONEncoder) EncodeResponse(res SearchcodeResponse) (skipped []SearchcodeAddressItem, err error) {
+	for _, item := range res.Addresses {
+		ok, err := e.Encode(item)
+		if err != nil {
+			return skipped, err
+		}
+		if !ok {
+			skipped = append(skipped, item)
+		}
+	}
+	return skipped, nil
+}
+
+// Count はこれまでに書き出した Feature の件数を返します。
+func (e *GeoJSONEncoder) Count() int {
+	return e.count
+}
+
+// Skipped は緯度・経度が nil のため書き出さなかった住所アイテムの件数を返します。
+func (e *GeoJSONEncoder) Skipped() int {
+	return e.skipped
+}
+
+// Close は FeatureCollection を閉じます。
+// 1件も書き出していない場合でも、空の FeatureCollection として有効な GeoJSON を出力します。
+func (e *GeoJSONEncoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+
+	suffix := "]}"
+	if !e.started {
+		suffix = `{"type":"FeatureCollection","features":[]}`
+	}
+	if _, err := io.WriteString(e.w, suffix); err != nil {
+		return errors.Join(NewError(500, "write error"), err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchcodeResponse_ToGeoJSON(t *testing.T) {
+	t.Parallel()
+
+	biz := "日本郵便株式会社"
+	item := geoItem("1008798", 35.6795, 139.7648)
+	item.PrefName = "東京都"
+	item.BizName = &biz
+	res := yd4b.SearchcodeResponse{Addresses: []yd4b.SearchcodeAddressItem{item, {ZipCode: "0000000"}}}
+
+	fc, skipped := res.ToGeoJSON()
+	assert.Equal(t, "FeatureCollection", fc.Type)
+	assert.Len(t, fc.Features, 1)
+	assert.Len(t, skipped, 1)
+	assert.Equal(t, "0000000", skipped[0].ZipCode)
+
+	f := fc.Features[0]
+	assert.Equal(t, "Feature", f.Type)
+	assert.Equal(t, "Point", f.Geometry.Type)
+	assert.Equal(t, []float64{139.7648, 35.6795}, f.Geometry.Coordinates)
+	assert.Equal(t, "1008798", f.Properties["zip_code"])
+	assert.Equal(t, "東京都", f.Properties["pref_name"])
+	assert.Equal(t, biz, f.Properties["biz_name"])
+	assert.NotContains(t, f.Properties, "block_name")
+}
+
+func TestNewGeoJSONFeatureCollection(t *testing.T) {
+	t.Parallel()
+
+	fc, skipped := yd4b.NewGeoJSONFeatureCollection()
+	assert.Empty(t, skipped)
+	b, err := json.Marshal(fc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, string(b))
+
+	fc, _ = yd4b.NewGeoJSONFeatureCollection(
+		yd4b.SearchcodeResponse{Addresses: []yd4b.SearchcodeAddressItem{geoItem("a", 1, 2)}},
+		yd4b.SearchcodeResponse{Addresses: []yd4b.SearchcodeAddressItem{geoItem("b", 3, 4)}},
+	)
+	assert.Len(t, fc.Features, 2)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("fail") }
+
+func TestGeoJSONEncoder(t *testing.T) {
+	tests := []struct {
+		name        string
+		responses   []yd4b.SearchcodeResponse
+		wantCount   int
+		wantSkipped int
+	}{
+		{
+			name:      "empty",
+			wantCount: 0,
+		},
+		{
+			name: "multiple responses",
+			responses: []yd4b.SearchcodeResponse{
+				{Addresses: []yd4b.SearchcodeAddressItem{geoItem("a", 1, 2), {ZipCode: "nil"}}},
+				{Addresses: []yd4b.SearchcodeAddressItem{geoItem("b", 3, 4)}},
+			},
+			wantCount:   2,
+			wantSkipped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			enc := yd4b.NewGeoJSONEncoder(&buf)
+			for _, res := range tt.responses {
+				_, err := enc.EncodeResponse(res)
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, enc.Close())
+			assert.NoError(t, enc.Close())
+
+			var fc yd4b.GeoJSONFeatureCollection
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+			assert.Equal(t, "FeatureCollection", fc.Type)
+			assert.Len(t, fc.Features, tt.wantCount)
+			assert.Equal(t, tt.wantCount, enc.Count())
+			assert.Equal(t, tt.wantSkipped, enc.Skipped())
+
+			_, err := enc.Encode(geoItem("c", 5, 6))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGeoJSONEncoder_WriteError(t *testing.T) {
+	t.Parallel()
+
+	enc := yd4b.NewGeoJSONEncoder(failingWriter{})
+	_, err := enc.Encode(geoItem("a", 1, 2))
+	assert.ErrorContains(t, err, "write error")
+	assert.ErrorContains(t, enc.Close(), "write error")
+}
+
+// flakyWriter は最初の fails 回の書き込みのみ失敗する io.Writer です。
+type flakyWriter struct {
+	buf   bytes.Buffer
+	fails int
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if w.fails > 0 {
+		w.fails--
+		return 0, errors.New("fail")
+	}
+	return w.buf.Write(p)
+}
+
+func TestGeoJSONEncoder_HeaderWriteError(t *testing.T) {
+	t.Parallel()
+
+	// 先頭の書き込みに失敗した場合でも、Close は有効な GeoJSON を出力する
+	w := &flakyWriter{fails: 1}
+	enc := yd4b.NewGeoJSONEncoder(w)
+	_, err := enc.Encode(geoItem("a", 1, 2))
+	assert.ErrorContains(t, err, "write error")
+	require.NoError(t, enc.Close())
+
+	var fc yd4b.GeoJSONFeatureCollection
+	require.NoError(t, json.Unmarshal(w.buf.Bytes(), &fc))
+	assert.Equal(t, "FeatureCollection", fc.Type)
+	assert.Empty(t, fc.Features)
+}
@@ -0,0 +1,157 @@
+package yd4b
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HedgePolicy は [Client.SetHedgePolicy] の設定です。
+type HedgePolicy struct {
+	Delay       time.Duration // この時間内にレスポンスがなければ同じリクエストを追加で送信する
+	MaxAttempts int           // 同時に送信するリクエストの最大数（0 の場合は 2）
+	AllowPOST   bool          // POST リクエスト（addresszip、トークン取得）にも適用するかどうか
+}
+
+// SetHedgePolicy はヘッジリクエストの設定を行います。
+// 最初のリクエストから Delay が経過してもレスポンスがない場合、同じリクエストを追加で送信し、最初に届いたレスポンスを使います。
+// 使われなかったリクエストはコンテキストのキャンセルによって中断されます。
+// 既定では冪等な GET リクエスト（searchcode）にのみ適用され、POST リクエストには AllowPOST を指定した場合にのみ適用されます。
+// nil を指定すると無効になります。
+func (c *Client) SetHedgePolicy(policy *HedgePolicy) {
+	if policy == nil {
+		c.hedge = nil
+		return
+	}
+	p := *policy
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 2
+	}
+	c.hedge = &p
+}
+
+// hedgeable はリクエストにヘッジを適用できるかどうかを返します。
+func (p *HedgePolicy) hedgeable(req *http.Request) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	// 追加のリクエストにボディを複製できない場合は適用しない
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return p.AllowPOST
+	}
+	return false
+}
+
+// hedgeResult は1つのリクエストの結果です。
+type hedgeResult struct {
+	resp    *http.Response
+	err     error
+	attempt int // 何番目に送信したリクエストか
+}
+
+// cancelOnClose はボディを閉じたときにリクエストのコンテキストをキャンセルする io.ReadCloser です。
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// hedgedDo は HedgePolicy に従ってリクエストを送信します。
+// 最初に届いたレスポンスを返し、残りのリクエストはキャンセルしてレスポンスを破棄します。
+// すべてのリクエストが通信エラーになった場合は最後のエラーを返します。
+func (c *Client) hedgedDo(req *http.Request) (*http.Response, error) {
+	p := c.hedge
+	if !p.hedgeable(req) {
+		return c.doFunc(req)
+	}
+
+	results := make(chan hedgeResult, p.MaxAttempts)
+	cancels := make([]context.CancelFunc, 0, p.MaxAttempts)
+	pending := 0
+	launch := func() error {
+		ctx, cancel := context.WithCancel(req.Context())
+		r := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return err
+			}
+			r.Body = body
+		}
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		pending++
+		go func() {
+			resp, err := c.doFunc(r)
+			results <- hedgeResult{resp: resp, err: err, attempt: attempt}
+		}()
+		return nil
+	}
+	// winner 以外のリクエストを中断し、届いたレスポンスを破棄する（winner が -1 の場合はすべて）
+	discard := func(winner int) {
+		for i, cancel := range cancels {
+			if i != winner {
+				cancel()
+			}
+		}
+		go func(n int) {
+			for range n {
+				if res := <-results; res.resp != nil {
+					res.resp.Body.Close()
+				}
+			}
+		}(pending)
+	}
+
+	if err := launch(); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(p.Delay)
+	defer timer.Stop()
+	var lastErr error
+	for {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				discard(res.attempt)
+				res.resp.Body = &cancelOnClose{ReadCloser: res.resp.Body, cancel: cancels[res.attempt]}
+				return res.resp, nil
+			}
+			cancels[res.attempt]()
+			lastErr = res.err
+			if len(cancels) < p.MaxAttempts && req.Context().Err() == nil {
+				// 通信エラーの場合は待たずに次のリクエストを送信する
+				if err := launch(); err != nil {
+					discard(-1)
+					return nil, err
+				}
+				continue
+			}
+			if pending == 0 {
+				return nil, lastErr
+			}
+		case <-timer.C:
+			if len(cancels) < p.MaxAttempts {
+				if err := launch(); err != nil {
+					discard(-1)
+					return nil, err
+				}
+				timer.Reset(p.Delay)
+			}
+		}
+	}
+}
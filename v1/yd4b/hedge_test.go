@@ -0,0 +1,151 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowFirstDoFunc は1回目のリクエストだけキャンセルされるまで応答しない doFunc です。
+// 各リクエストの番号をレスポンスの count に設定し、キャンセルされたリクエストの数を canceled に数えます。
+func slowFirstDoFunc(calls, canceled *int32) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(calls, 1)
+		if n == 1 {
+			<-req.Context().Done()
+			atomic.AddInt32(canceled, 1)
+			return nil, req.Context().Err()
+		}
+		var body []byte
+		if req.Body != nil {
+			body, _ = io.ReadAll(req.Body)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Request-Body": {string(body)}},
+			Body:       io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"count":%d}`, n))),
+		}, nil
+	}
+}
+
+func TestClient_Hedge(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    *yd4b.HedgePolicy
+		call      func(ctx context.Context, c *yd4b.Client) (int, error)
+		wantCalls int32
+		wantCount int
+	}{
+		{
+			name:   "searchcode is hedged",
+			policy: &yd4b.HedgePolicy{Delay: 10 * time.Millisecond},
+			call: func(ctx context.Context, c *yd4b.Client) (int, error) {
+				res, err := c.SearchcodeContext(ctx, "1000001")
+				return res.Count, err
+			},
+			wantCalls: 2,
+			wantCount: 2,
+		},
+		{
+			name:   "addresszip is hedged when allowed",
+			policy: &yd4b.HedgePolicy{Delay: 10 * time.Millisecond, AllowPOST: true},
+			call: func(ctx context.Context, c *yd4b.Client) (int, error) {
+				res, meta, err := c.AddressZipWithMeta(ctx, yd4b.WithPrefCode("13"))
+				if err == nil && meta.Header.Get("X-Request-Body") != `{"pref_code":"13"}` {
+					return 0, errors.New("request body was not copied: " + meta.Header.Get("X-Request-Body"))
+				}
+				return res.Count, err
+			},
+			wantCalls: 2,
+			wantCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls, canceled int32
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(slowFirstDoFunc(&calls, &canceled))
+			client.SetHedgePolicy(tt.policy)
+
+			count, err := tt.call(context.Background(), client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+			// 使われなかったリクエストはキャンセルされる
+			assert.Eventually(t, func() bool { return atomic.LoadInt32(&canceled) == 1 }, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestClient_Hedge_NotAppliedToPOST(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetHedgePolicy(&yd4b.HedgePolicy{Delay: time.Millisecond})
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	})
+
+	_, err := client.AddressZip(yd4b.WithPrefCode("13"))
+	require.NoError(t, err)
+	_, err = client.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestClient_Hedge_FastResponse(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetHedgePolicy(&yd4b.HedgePolicy{Delay: time.Second})
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"count":1}`))}, nil
+	})
+
+	res, err := client.Searchcode("1000001")
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Count)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "no hedge when the first response is fast")
+}
+
+func TestClient_Hedge_RetriesTransportError(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetHedgePolicy(&yd4b.HedgePolicy{Delay: time.Hour, MaxAttempts: 3})
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return nil, errors.New("connection reset")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"count":3}`))}, nil
+	})
+
+	res, err := client.Searchcode("1000001")
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Count)
+
+	// すべて失敗した場合は最後のエラーを返す
+	atomic.StoreInt32(&calls, -10)
+	_, err = client.Searchcode("1000001")
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, int32(-7), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,25 @@
+// Package apispec は OpenAPI specification に定義されているエンドポイントの一覧を提供します。
+//
+// yd4b パッケージのリクエスト・レスポンスの型は、同じ openapi.json から yd4b パッケージ側で生成しています。
+// この一覧は、すべてのエンドポイントとパラメータに Client のメソッドやオプションがあることをテストで確認するために使います。
+// 現在の openapi.json は公式の specification ではなく yd4b-go の実装から書き起こしたものなので、
+// 公式の specification に置き換えるまでは実装と API とのずれを検出できません。
+// openapi.json を置き換えたら openapi.json.sha256 を更新し、go generate を実行してください。
+package apispec
+
+//go:generate go run ./gen -spec openapi.json -checksum openapi.json.sha256 -operations -out apispec_gen.go -package apispec
+
+// Operation は specification に定義されているエンドポイントです。
+type Operation struct {
+	ID         string      // operationId
+	Method     string      // HTTPメソッド
+	Path       string      // パス（パスパラメータは {name} の形式）
+	Parameters []Parameter // パスパラメータとクエリパラメータ
+}
+
+// Parameter はエンドポイントのパスパラメータまたはクエリパラメータです。
+type Parameter struct {
+	Name string // パラメータ名
+	In   string // 指定する場所（"path" または "query"）
+	Type string // 対応する Go の型
+}
@@ -0,0 +1,27 @@
+// Code generated by gen from openapi.json. DO NOT EDIT.
+
+package apispec
+
+// Operations は specification に定義されているエンドポイントの一覧です。
+var Operations = []Operation{
+	{
+		ID: "addresszip", Method: "POST", Path: "/api/v1/addresszip",
+		Parameters: []Parameter{
+			{Name: "ec_uid", In: "query", Type: "string"},
+		},
+	},
+	{
+		ID: "searchcode", Method: "GET", Path: "/api/v1/searchcode/{search_code}",
+		Parameters: []Parameter{
+			{Name: "search_code", In: "path", Type: "string"},
+			{Name: "page", In: "query", Type: "int"},
+			{Name: "limit", In: "query", Type: "int"},
+			{Name: "choikitype", In: "query", Type: "int"},
+			{Name: "searchtype", In: "query", Type: "int"},
+			{Name: "ec_uid", In: "query", Type: "string"},
+		},
+	},
+	{
+		ID: "token", Method: "POST", Path: "/api/v1/j/token",
+	},
+}
@@ -0,0 +1,373 @@
+// gen は OpenAPI specification から Go のコードを生成するコマンドです。
+//
+// 使い方:
+//
+//	go run ./internal/apispec/gen -spec internal/apispec/openapi.json -checksum internal/apispec/openapi.json.sha256 -types apitypes.json -out apitypes_gen.go -package yd4b
+//	go run ./gen -spec openapi.json -checksum openapi.json.sha256 -operations -out apispec_gen.go -package apispec
+//
+// -types を指定した場合、設定ファイルに列挙したスキーマをリクエスト・レスポンスの型として生成します。
+// -operations を指定した場合、specification に定義されているエンドポイントの一覧を生成します。
+// -checksum を指定した場合、specification の SHA-256 が記録された値と一致しなければ生成しません。
+// 入力となる specification が記録なしに書き換えられることを防ぎます。
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+// spec は OpenAPI specification のうち生成に使う部分です。
+type spec struct {
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components struct {
+		Schemas map[string]schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// operation は1つのエンドポイントの定義です。
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Parameters  []parameter `json:"parameters"`
+}
+
+// parameter はパスパラメータやクエリパラメータの定義です。
+type parameter struct {
+	Name   string `json:"name"`
+	In     string `json:"in"`
+	Schema schema `json:"schema"`
+}
+
+// schema はスキーマの定義です。
+type schema struct {
+	Ref         string     `json:"$ref"`
+	Type        string     `json:"type"`
+	Format      string     `json:"format"`
+	Description string     `json:"description"`
+	Nullable    bool       `json:"nullable"`
+	Properties  properties `json:"properties"`
+	Items       *schema    `json:"items"`
+}
+
+// property はスキーマのプロパティです。
+type property struct {
+	name   string
+	schema schema
+}
+
+// properties は specification に記載された順序を保ったプロパティの一覧です。
+type properties []property
+
+// UnmarshalJSON はプロパティを specification に記載された順序のままデコードします。
+func (p *properties) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("properties must be an object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var s schema
+		if err := dec.Decode(&s); err != nil {
+			return err
+		}
+		*p = append(*p, property{name: tok.(string), schema: s})
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// config は生成する型の設定です。
+type config struct {
+	Types []typeConfig `json:"types"`
+}
+
+// typeConfig は1つのスキーマから生成する型の設定です。
+type typeConfig struct {
+	Schema    string                 `json:"schema"`    // スキーマ名
+	Name      string                 `json:"name"`      // 型名（省略時はスキーマ名から変換）
+	Doc       string                 `json:"doc"`       // 型のドキュメントコメント（改行で複数行）
+	OmitEmpty bool                   `json:"omitempty"` // json タグに omitempty を付けるか
+	Extras    bool                   `json:"extras"`    // 定義されていないフィールドを格納する Extras を持つか
+	Fields    map[string]fieldConfig `json:"fields"`    // プロパティごとの設定
+}
+
+// fieldConfig は1つのプロパティから生成するフィールドの設定です。
+type fieldConfig struct {
+	Name string `json:"name"` // フィールド名（省略時はプロパティ名から変換）
+	Doc  string `json:"doc"`  // コメント（省略時は specification の description）
+}
+
+// loadConfig は生成する型の設定ファイルを読み込みます。
+func loadConfig(path string) (config, error) {
+	var cfg config
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	err = json.Unmarshal(b, &cfg)
+	return cfg, err
+}
+
+// initialisms は Go の命名規則で大文字にする略語です。
+var initialisms = map[string]string{"id": "ID", "uid": "UID", "url": "URL", "ip": "IP"}
+
+// goName はスネークケースの名前を Go の公開名に変換します。
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		if v, ok := initialisms[part]; ok {
+			b.WriteString(v)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// refName は $ref が指すスキーマの名前を返します。
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// goType はスキーマに対応する Go の型を返します。
+// names は $ref が指すスキーマの型名を変更する場合の対応です。
+func goType(s schema, names map[string]string) (string, error) {
+	var t string
+	switch {
+	case s.Ref != "":
+		t = goName(refName(s.Ref))
+		if name, ok := names[refName(s.Ref)]; ok {
+			t = name
+		}
+	case s.Type == "string":
+		t = "string"
+	case s.Type == "integer" && s.Format == "int64":
+		t = "int64"
+	case s.Type == "integer":
+		t = "int"
+	case s.Type == "number":
+		t = "float64"
+	case s.Type == "boolean":
+		t = "bool"
+	case s.Type == "array" && s.Items != nil:
+		elem, err := goType(*s.Items, names)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	default:
+		return "", fmt.Errorf("unsupported schema type %q", s.Type)
+	}
+	if s.Nullable {
+		t = "*" + t
+	}
+	return t, nil
+}
+
+// generator は生成するコードを組み立てます。
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// field は生成する構造体のフィールドです。
+type field struct {
+	name, typ, tag, desc string
+}
+
+// schemaFields はスキーマのプロパティを specification に記載された順序のフィールドに変換します。
+func schemaFields(s schema, tc typeConfig, names map[string]string) ([]field, error) {
+	fields := make([]field, 0, len(s.Properties))
+	for _, prop := range s.Properties {
+		typ, err := goType(prop.schema, names)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", prop.name, err)
+		}
+		fc := tc.Fields[prop.name]
+		name := fc.Name
+		if name == "" {
+			name = goName(prop.name)
+		}
+		desc := fc.Doc
+		if desc == "" {
+			desc = prop.schema.Description
+			if prop.schema.Nullable {
+				desc += "（nullable）"
+			}
+		}
+		tag := prop.name
+		if tc.OmitEmpty {
+			tag += ",omitempty"
+		}
+		fields = append(fields, field{name: name, typ: typ, tag: fmt.Sprintf(`json:"%s"`, tag), desc: desc})
+	}
+	for name := range tc.Fields {
+		if !slices.ContainsFunc(s.Properties, func(p property) bool { return p.name == name }) {
+			return nil, fmt.Errorf("field %s is not in the schema", name)
+		}
+	}
+	return fields, nil
+}
+
+// generateTypes は cfg に列挙したスキーマから型を生成します。
+func generateTypes(sp spec, pkg string, cfg config) ([]byte, error) {
+	names := map[string]string{}
+	for _, tc := range cfg.Types {
+		if tc.Name != "" {
+			names[tc.Schema] = tc.Name
+		}
+	}
+
+	var g generator
+	g.printf("// Code generated by gen from openapi.json. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+	if slices.ContainsFunc(cfg.Types, func(tc typeConfig) bool { return tc.Extras }) {
+		g.printf("import \"encoding/json\"\n\n")
+	}
+	for _, tc := range cfg.Types {
+		s, ok := sp.Components.Schemas[tc.Schema]
+		if !ok {
+			return nil, fmt.Errorf("schema %s is not in the spec", tc.Schema)
+		}
+		fields, err := schemaFields(s, tc, names)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", tc.Schema, err)
+		}
+
+		name := tc.Name
+		if name == "" {
+			name = goName(tc.Schema)
+		}
+		doc := tc.Doc
+		if doc == "" {
+			doc = name + " は " + tc.Schema + " スキーマです。"
+		}
+		for _, line := range strings.Split(doc, "\n") {
+			g.printf("// %s\n", line)
+		}
+		g.printf("type %s struct {\n", name)
+		for _, f := range fields {
+			g.printf("\t%s %s `%s` // %s\n", f.name, f.typ, f.tag, f.desc)
+		}
+		if tc.Extras {
+			g.printf("\n\tExtras map[string]json.RawMessage `json:\"-\"` // 構造体に定義されていないフィールド（ない場合は nil）\n")
+		}
+		g.printf("}\n\n")
+	}
+	return format.Source(g.buf.Bytes())
+}
+
+// generateOperations は specification に定義されているエンドポイントの一覧を生成します。
+func generateOperations(sp spec, pkg string) ([]byte, error) {
+	type op struct {
+		method, path string
+		operation
+	}
+	var ops []op
+	for path, methods := range sp.Paths {
+		for method, o := range methods {
+			ops = append(ops, op{strings.ToUpper(method), path, o})
+		}
+	}
+	slices.SortFunc(ops, func(a, b op) int { return strings.Compare(a.OperationID, b.OperationID) })
+
+	var g generator
+	g.printf("// Code generated by gen from openapi.json. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+	g.printf("// Operations は specification に定義されているエンドポイントの一覧です。\n")
+	g.printf("var Operations = []Operation{\n")
+	for _, o := range ops {
+		g.printf("\t{\n\t\tID: %q, Method: %q, Path: %q,\n", o.OperationID, o.method, o.path)
+		if len(o.Parameters) > 0 {
+			g.printf("\t\tParameters: []Parameter{\n")
+			for _, p := range o.Parameters {
+				typ, err := goType(p.Schema, nil)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s parameter %s: %w", o.method, o.path, p.Name, err)
+				}
+				g.printf("\t\t\t{Name: %q, In: %q, Type: %q},\n", p.Name, p.In, typ)
+			}
+			g.printf("\t\t},\n")
+		}
+		g.printf("\t},\n")
+	}
+	g.printf("}\n")
+	return format.Source(g.buf.Bytes())
+}
+
+// verifyChecksum は specification の SHA-256 が sha256sum 形式の sums に記録された値と一致するかを確認します。
+func verifyChecksum(b []byte, sums []byte) error {
+	fields := strings.Fields(string(sums))
+	if len(fields) == 0 {
+		return errors.New("checksum file is empty")
+	}
+	sum := sha256.Sum256(b)
+	if got := hex.EncodeToString(sum[:]); got != strings.ToLower(fields[0]) {
+		return fmt.Errorf("spec checksum mismatch: got %s, want %s", got, fields[0])
+	}
+	return nil
+}
+
+func main() {
+	specPath := flag.String("spec", "openapi.json", "OpenAPI specification のパス")
+	checksum := flag.String("checksum", "", "specification の SHA-256 を記録したファイルのパス（sha256sum 形式）")
+	types := flag.String("types", "", "生成する型の設定ファイルのパス")
+	operations := flag.Bool("operations", false, "エンドポイントの一覧を生成する")
+	out := flag.String("out", "apispec_gen.go", "出力先のパス")
+	pkg := flag.String("package", "apispec", "出力するパッケージ名")
+	flag.Parse()
+	if (*types == "") == !*operations {
+		log.Fatal("specify exactly one of -types or -operations")
+	}
+
+	b, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *checksum != "" {
+		sums, err := os.ReadFile(*checksum)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := verifyChecksum(b, sums); err != nil {
+			log.Fatal(err)
+		}
+	}
+	var sp spec
+	if err := json.Unmarshal(b, &sp); err != nil {
+		log.Fatal(err)
+	}
+
+	var src []byte
+	if *operations {
+		src, err = generateOperations(sp, *pkg)
+	} else {
+		var cfg config
+		if cfg, err = loadConfig(*types); err == nil {
+			src, err = generateTypes(sp, *pkg, cfg)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
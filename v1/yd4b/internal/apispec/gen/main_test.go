@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "zip_code", want: "ZipCode"},
+		{in: "client_id", want: "ClientID"},
+		{in: "ec_uid", want: "EcUID"},
+		{in: "dgacode", want: "Dgacode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, goName(tt.in))
+		})
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    schema
+		names map[string]string
+		want  string
+	}{
+		{name: "string", in: schema{Type: "string"}, want: "string"},
+		{name: "nullable string", in: schema{Type: "string", Nullable: true}, want: "*string"},
+		{name: "int64", in: schema{Type: "integer", Format: "int64"}, want: "int64"},
+		{name: "nullable number", in: schema{Type: "number", Nullable: true}, want: "*float64"},
+		{name: "array of refs", in: schema{Type: "array", Items: &schema{Ref: "#/components/schemas/address_item"}}, want: "[]AddressItem"},
+		{name: "renamed ref", in: schema{Ref: "#/components/schemas/AddressZipResponse"}, names: map[string]string{"AddressZipResponse": "AddressResponse"}, want: "AddressResponse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := goType(tt.in, tt.names)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	tests := []struct {
+		name          string
+		sums          string
+		wantErrSubstr string
+	}{
+		// echo -n '{}' | sha256sum
+		{name: "match", sums: "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a  openapi.json\n"},
+		{name: "mismatch", sums: "0000000000000000000000000000000000000000000000000000000000000000  openapi.json\n", wantErrSubstr: "spec checksum mismatch"},
+		{name: "empty", sums: "", wantErrSubstr: "checksum file is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := verifyChecksum([]byte("{}"), []byte(tt.sums))
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerateTypes(t *testing.T) {
+	t.Parallel()
+
+	var sp spec
+	require.NoError(t, json.Unmarshal([]byte(`{"components":{"schemas":{
+		"Item":{"properties":{
+			"zip_code":{"type":"string","description":"郵便番号"},
+			"dgacode":{"type":"string","nullable":true,"description":"デジタルアドレスコード"}
+		}},
+		"ItemList":{"properties":{
+			"items":{"type":"array","items":{"$ref":"#/components/schemas/Item"}},
+			"count":{"type":"integer","description":"件数"}
+		}}
+	}}}`), &sp))
+	cfg := config{Types: []typeConfig{
+		{Schema: "Item", Name: "item", Doc: "item は住所です。", OmitEmpty: true, Extras: true, Fields: map[string]fieldConfig{"dgacode": {Name: "DgaCode"}}},
+		{Schema: "ItemList", Fields: map[string]fieldConfig{"items": {Doc: "住所の一覧"}}},
+	}}
+
+	got, err := generateTypes(sp, "yd4b", cfg)
+	require.NoError(t, err)
+	// プロパティは specification に記載された順序で、nullable はポインタとして生成される
+	assert.Equal(t, `// Code generated by gen from openapi.json. DO NOT EDIT.
+
+package yd4b
+
+import "encoding/json"
+
+// item は住所です。
+type item struct {
+	ZipCode string  `+"`"+`json:"zip_code,omitempty"`+"`"+` // 郵便番号
+	DgaCode *string `+"`"+`json:"dgacode,omitempty"`+"`"+`  // デジタルアドレスコード（nullable）
+
+	Extras map[string]json.RawMessage `+"`"+`json:"-"`+"`"+` // 構造体に定義されていないフィールド（ない場合は nil）
+}
+
+// ItemList は ItemList スキーマです。
+type ItemList struct {
+	Items []item `+"`"+`json:"items"`+"`"+` // 住所の一覧
+	Count int    `+"`"+`json:"count"`+"`"+` // 件数
+}
+`, string(got))
+
+	_, err = generateTypes(sp, "yd4b", config{Types: []typeConfig{{Schema: "Missing"}}})
+	assert.ErrorContains(t, err, "schema Missing is not in the spec")
+	_, err = generateTypes(sp, "yd4b", config{Types: []typeConfig{{Schema: "Item", Fields: map[string]fieldConfig{"dga_code": {Name: "DgaCode"}}}}})
+	assert.ErrorContains(t, err, "field dga_code is not in the schema")
+}
+
+func TestGenerate_UpToDate(t *testing.T) {
+	t.Parallel()
+
+	// 生成済みのコードが openapi.json と一致していることを確認する（go generate の実行漏れを検出する）
+	b, err := os.ReadFile("../openapi.json")
+	require.NoError(t, err)
+	// openapi.json が記録された checksum と一致することを確認する（記録なしの書き換えを検出する）
+	sums, err := os.ReadFile("../openapi.json.sha256")
+	require.NoError(t, err)
+	require.NoError(t, verifyChecksum(b, sums), "openapi.json was modified; update openapi.json.sha256 only when replacing it with a verified copy")
+	var sp spec
+	require.NoError(t, json.Unmarshal(b, &sp))
+
+	ops, err := generateOperations(sp, "apispec")
+	require.NoError(t, err)
+	want, err := os.ReadFile("../apispec_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(ops), "apispec_gen.go is stale; run go generate ./v1/yd4b/internal/apispec")
+
+	cfg, err := loadConfig("../../../apitypes.json")
+	require.NoError(t, err)
+	types, err := generateTypes(sp, "yd4b", cfg)
+	require.NoError(t, err)
+	want, err = os.ReadFile("../../../apitypes_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(types), "apitypes_gen.go is stale; run go generate ./v1/yd4b")
+}
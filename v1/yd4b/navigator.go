@@ -0,0 +1,192 @@
+package yd4b
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// AddressResponse.Level の値です。検索条件がどの階層まで一致したかを表します。
+const (
+	LevelPref = 1 // 都道府県まで一致
+	LevelCity = 2 // 市区町村まで一致
+	LevelTown = 3 // 町域まで一致
+)
+
+// defaultNavigatorPageSize は [Navigator] が1ページあたりに取得する件数の既定値です。
+const defaultNavigatorPageSize = 100
+
+// AddressZipAll は [Client.AddressZipContext] を繰り返し呼び出し、全ページの結果をまとめて返します。
+// 戻り値の Page と Limit は最後に取得したページのものです。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - pageSize: 1ページあたりの取得件数
+//   - opts: 検索条件を指定する addressRequestOption（ページ番号の指定は無視されます）
+func (c *Client) AddressZipAll(ctx context.Context, pageSize int, opts ...addressRequestOption) (res AddressResponse, err error) {
+	addresses := []AddressItem{}
+	for page := 1; ; page++ {
+		var r AddressResponse
+		r, err = c.AddressZipContext(ctx, slices.Concat(opts, []addressRequestOption{WithAZPage(page), WithAZLimit(pageSize)})...)
+		if err != nil {
+			return
+		}
+		addresses = append(addresses, r.Addresses...)
+		res = r
+		if len(r.Addresses) == 0 || len(addresses) >= r.Count {
+			break
+		}
+	}
+	res.Addresses = addresses
+	return
+}
+
+// Prefecture は都道府県を表す構造体です。
+type Prefecture struct {
+	Code string `json:"code"` // 都道府県コード
+	Name string `json:"name"` // 都道府県名
+	Kana string `json:"kana"` // 都道府県名（カナ）
+	Roma string `json:"roma"` // 都道府県名（ローマ字）
+}
+
+// City は市区町村を表す構造体です。
+type City struct {
+	PrefCode string `json:"pref_code"` // 都道府県コード
+	Code     string `json:"code"`      // 市区町村コード
+	Name     string `json:"name"`      // 市区町村名
+	Kana     string `json:"kana"`      // 市区町村名（カナ）
+	Roma     string `json:"roma"`      // 市区町村名（ローマ字）
+}
+
+// Town は町域を表す構造体です。
+type Town struct {
+	PrefCode string   `json:"pref_code"` // 都道府県コード
+	CityCode string   `json:"city_code"` // 市区町村コード
+	Name     string   `json:"name"`      // 町域名
+	Kana     string   `json:"kana"`      // 町域名（カナ）
+	Roma     string   `json:"roma"`      // 町域名（ローマ字）
+	ZipCodes []string `json:"zip_codes"` // 町域に属する郵便番号
+}
+
+// Navigator は都道府県 → 市区町村 → 町域 → 郵便番号の順に住所を絞り込むための API を提供します。
+// 連動するセレクトボックスなどの構築に利用できます。
+type Navigator struct {
+	client   *Client
+	pageSize int
+}
+
+// NewNavigator は [Navigator] を生成します。
+func NewNavigator(client *Client) *Navigator {
+	return &Navigator{client: client, pageSize: defaultNavigatorPageSize}
+}
+
+// SetPageSize は1ページあたりの取得件数を設定します。
+func (n *Navigator) SetPageSize(size int) {
+	n.pageSize = size
+}
+
+// fetch は全ページを取得し、一致した階層が level に達しているかを確認します。
+func (n *Navigator) fetch(ctx context.Context, level int, opts ...addressRequestOption) ([]AddressItem, error) {
+	res, err := n.client.AddressZipAll(ctx, n.pageSize, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if res.Level < level || len(res.Addresses) == 0 {
+		return nil, NewError(http.StatusNotFound, "address not found")
+	}
+	return res.Addresses, nil
+}
+
+// Prefectures は都道府県の一覧を返します。
+func (n *Navigator) Prefectures(ctx context.Context) ([]Prefecture, error) {
+	items, err := n.fetch(ctx, LevelPref, WithFlgGetPref(1))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	prefs := []Prefecture{}
+	for _, item := range items {
+		if seen[item.PrefCode] {
+			continue
+		}
+		seen[item.PrefCode] = true
+		prefs = append(prefs, Prefecture{Code: item.PrefCode, Name: item.PrefName, Kana: item.PrefKana, Roma: item.PrefRoma})
+	}
+	return prefs, nil
+}
+
+// Cities は都道府県に属する市区町村の一覧を返します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - prefCode: 都道府県コード
+func (n *Navigator) Cities(ctx context.Context, prefCode string) ([]City, error) {
+	items, err := n.fetch(ctx, LevelCity, WithPrefCode(prefCode), WithFlgGetCity(1))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	cities := []City{}
+	for _, item := range items {
+		if seen[item.CityCode] {
+			continue
+		}
+		seen[item.CityCode] = true
+		cities = append(cities, City{PrefCode: item.PrefCode, Code: item.CityCode, Name: item.CityName, Kana: item.CityKana, Roma: item.CityRoma})
+	}
+	return cities, nil
+}
+
+// Towns は市区町村に属する町域の一覧を返します。
+// 同じ町域名に複数の郵便番号がある場合は1件にまとめられます。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - cityCode: 市区町村コード
+func (n *Navigator) Towns(ctx context.Context, cityCode string) ([]Town, error) {
+	items, err := n.fetch(ctx, LevelTown, WithCityCode(cityCode))
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int)
+	towns := []Town{}
+	for _, item := range items {
+		i, ok := index[item.TownName]
+		if !ok {
+			i = len(towns)
+			index[item.TownName] = i
+			towns = append(towns, Town{PrefCode: item.PrefCode, CityCode: item.CityCode, Name: item.TownName, Kana: item.TownKana, Roma: item.TownRoma})
+		}
+		if !slices.Contains(towns[i].ZipCodes, item.ZipCode) {
+			towns[i].ZipCodes = append(towns[i].ZipCodes, item.ZipCode)
+		}
+	}
+	return towns, nil
+}
+
+// ZipCodes は町域に対応する住所アイテム（郵便番号を含む）を返します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - cityCode: 市区町村コード
+//   - townName: 町域名
+func (n *Navigator) ZipCodes(ctx context.Context, cityCode string, townName string) ([]AddressItem, error) {
+	items, err := n.fetch(ctx, LevelTown, WithCityCode(cityCode), WithTownName(townName))
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []AddressItem{}
+	for _, item := range items {
+		if item.TownName == townName {
+			matched = append(matched, item)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, NewError(http.StatusNotFound, "address not found")
+	}
+	return matched, nil
+}
@@ -0,0 +1,167 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAddressZip はリクエストボディに応じてページ分割されたレスポンスを返す doFunc を生成します。
+func fakeAddressZip(t *testing.T, level int, items []yd4b.AddressItem, check func(b yd4b.AddressRequest)) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		var b yd4b.AddressRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&b))
+		if check != nil {
+			check(b)
+		}
+		start := (b.Page - 1) * b.Limit
+		end := min(start+b.Limit, len(items))
+		if start > len(items) {
+			start = len(items)
+		}
+		body, _ := json.Marshal(yd4b.AddressResponse{Level: level, Page: b.Page, Limit: b.Limit, Count: len(items), Addresses: items[start:end]})
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(body))}, nil
+	}
+}
+
+func TestClient_AddressZipAll(t *testing.T) {
+	t.Parallel()
+
+	items := []yd4b.AddressItem{{ZipCode: "1"}, {ZipCode: "2"}, {ZipCode: "3"}, {ZipCode: "4"}, {ZipCode: "5"}}
+	var pages []int
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(fakeAddressZip(t, yd4b.LevelTown, items, func(b yd4b.AddressRequest) {
+		assert.Equal(t, "13101", b.CityCode)
+		assert.Equal(t, 2, b.Limit)
+		pages = append(pages, b.Page)
+	}))
+
+	res, err := client.AddressZipAll(context.Background(), 2, yd4b.WithCityCode("13101"), yd4b.WithAZPage(9))
+	assert.NoError(t, err)
+	assert.Equal(t, items, res.Addresses)
+	assert.Equal(t, 5, res.Count)
+	assert.Equal(t, []int{1, 2, 3}, pages)
+}
+
+func TestNavigator_ZipCodesVerbatim(t *testing.T) {
+	t.Parallel()
+
+	// API から取得した町域名は、全角英数字や半角カナを含んでいても変換せずに送信する
+	town := "ＮＴＴﾋﾞﾙ１"
+	items := []yd4b.AddressItem{{ZipCode: "1000001", CityCode: "13101", TownName: town}}
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(fakeAddressZip(t, yd4b.LevelTown, items, func(b yd4b.AddressRequest) {
+		assert.Equal(t, town, b.TownName)
+	}))
+
+	got, err := yd4b.NewNavigator(client).ZipCodes(context.Background(), "13101", town)
+	assert.NoError(t, err)
+	assert.Equal(t, items, got)
+}
+
+func TestNavigator(t *testing.T) {
+	tokyo := []yd4b.AddressItem{
+		{ZipCode: "1000001", PrefCode: "13", PrefName: "東京都", CityCode: "13101", CityName: "千代田区", TownName: "千代田"},
+		{ZipCode: "1000002", PrefCode: "13", PrefName: "東京都", CityCode: "13101", CityName: "千代田区", TownName: "皇居外苑"},
+		{ZipCode: "1000004", PrefCode: "13", PrefName: "東京都", CityCode: "13101", CityName: "千代田区", TownName: "大手町"},
+		{ZipCode: "1008111", PrefCode: "13", PrefName: "東京都", CityCode: "13101", CityName: "千代田区", TownName: "千代田"},
+		{ZipCode: "1040061", PrefCode: "13", PrefName: "東京都", CityCode: "13102", CityName: "中央区", TownName: "銀座"},
+	}
+
+	tests := []struct {
+		name          string
+		level         int
+		items         []yd4b.AddressItem
+		call          func(n *yd4b.Navigator) (any, error)
+		want          any
+		wantErrSubstr string
+	}{
+		{
+			name:  "prefectures",
+			level: yd4b.LevelPref,
+			items: tokyo,
+			call: func(n *yd4b.Navigator) (any, error) {
+				return n.Prefectures(context.Background())
+			},
+			want: []yd4b.Prefecture{{Code: "13", Name: "東京都"}},
+		},
+		{
+			name:  "cities",
+			level: yd4b.LevelCity,
+			items: tokyo,
+			call: func(n *yd4b.Navigator) (any, error) {
+				return n.Cities(context.Background(), "13")
+			},
+			want: []yd4b.City{{PrefCode: "13", Code: "13101", Name: "千代田区"}, {PrefCode: "13", Code: "13102", Name: "中央区"}},
+		},
+		{
+			name:  "towns grouped by name",
+			level: yd4b.LevelTown,
+			items: tokyo[:4],
+			call: func(n *yd4b.Navigator) (any, error) {
+				return n.Towns(context.Background(), "13101")
+			},
+			want: []yd4b.Town{
+				{PrefCode: "13", CityCode: "13101", Name: "千代田", ZipCodes: []string{"1000001", "1008111"}},
+				{PrefCode: "13", CityCode: "13101", Name: "皇居外苑", ZipCodes: []string{"1000002"}},
+				{PrefCode: "13", CityCode: "13101", Name: "大手町", ZipCodes: []string{"1000004"}},
+			},
+		},
+		{
+			name:  "zip codes",
+			level: yd4b.LevelTown,
+			items: tokyo[:4],
+			call: func(n *yd4b.Navigator) (any, error) {
+				return n.ZipCodes(context.Background(), "13101", "千代田")
+			},
+			want: []yd4b.AddressItem{tokyo[0], tokyo[3]},
+		},
+		{
+			name:  "towns with insufficient level",
+			level: yd4b.LevelCity,
+			items: tokyo,
+			call: func(n *yd4b.Navigator) (any, error) {
+				return n.Towns(context.Background(), "99999")
+			},
+			wantErrSubstr: "address not found",
+		},
+		{
+			name:  "zip codes without matching town",
+			level: yd4b.LevelTown,
+			items: tokyo[:1],
+			call: func(n *yd4b.Navigator) (any, error) {
+				return n.ZipCodes(context.Background(), "13101", "大手町")
+			},
+			wantErrSubstr: "address not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(fakeAddressZip(t, tt.level, tt.items, nil))
+			n := yd4b.NewNavigator(client)
+			n.SetPageSize(2)
+
+			got, err := tt.call(n)
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				var e *yd4b.Error
+				assert.True(t, errors.As(err, &e))
+				assert.Equal(t, http.StatusNotFound, e.StatusCode)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// 住所の照合のための文字列正規化ユーティリティ
+//
+// NFKC 正規化に加えて、漢数字、丁目表記、旧字体、ヶ/ケ・之/ノ、ハイフン類など
+// 日本の住所に特有の表記揺れを吸収します。
+package normalize
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NFKC は文字列を NFKC 正規化します。
+// 全角英数字は半角に、半角カナは全角カナ（濁点・半濁点は合成済み）に変換されます。
+func NFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// Katakana は文字列を NFKC 正規化したうえで、ひらがなをカタカナに変換します。
+// 半角・全角カナやひらがなで書かれた *Kana フィールドの比較に利用します。
+func Katakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + ('ァ' - 'ぁ')
+		}
+		return r
+	}, NFKC(s))
+}
+
+// isHyphen はハイフン・ダッシュ・マイナス類の文字かどうかを判定します。
+func isHyphen(r rune) bool {
+	switch r {
+	case '-', '‐', '‑', '‒', '–', '—', '―', '−', '－', 'ｰ', '─', '━':
+		return true
+	}
+	return false
+}
+
+// Hyphens はハイフン・ダッシュ・マイナス類を半角ハイフンに統一します。
+// 長音符「ー」は数字に挟まれている場合のみハイフンとみなします。
+func Hyphens(s string) string {
+	rs := []rune(s)
+	for i, r := range rs {
+		if isHyphen(r) {
+			rs[i] = '-'
+			continue
+		}
+		if r == 'ー' && i > 0 && i+1 < len(rs) && unicode.IsDigit(rs[i-1]) && unicode.IsDigit(rs[i+1]) {
+			rs[i] = '-'
+		}
+	}
+	return string(rs)
+}
+
+// Input は API へ送信する入力値を正規化します。
+// NFKC 正規化と前後の空白の除去、連続する空白の圧縮のみを行い、表記そのものは変えません。
+func Input(s string) string {
+	return strings.Join(strings.Fields(NFKC(s)), " ")
+}
+
+// oldKanji は旧字体・異体字と新字体の対応です。
+var oldKanji = strings.NewReplacer(
+	"澤", "沢", "邊", "辺", "邉", "辺", "齋", "斎", "齊", "斉", "濱", "浜",
+	"櫻", "桜", "國", "国", "廣", "広", "龍", "竜", "髙", "高", "﨑", "崎",
+	"嶋", "島", "嶌", "島", "檜", "桧", "藏", "蔵", "學", "学", "驛", "駅",
+	"鹽", "塩", "縣", "県", "壽", "寿", "與", "与", "舊", "旧", "瀧", "滝",
+	"德", "徳", "惠", "恵", "莊", "荘", "榮", "栄", "實", "実", "冨", "富",
+	"淺", "浅", "關", "関", "槇", "槙", "眞", "真", "兒", "児", "當", "当",
+)
+
+// OldKanji は旧字体・異体字を新字体に変換します。
+func OldKanji(s string) string {
+	return oldKanji.Replace(s)
+}
+
+// isKanji は漢字かどうかを判定します。
+func isKanji(r rune) bool {
+	return unicode.Is(unicode.Han, r) || r == '々'
+}
+
+// Variants は住所で表記が揺れやすい文字を統一します。
+//   - 漢字に挟まれた「ヶ」「ヵ」「ケ」「が」「ガ」は「ケ」に統一（霞ヶ関/霞が関/霞ケ関）
+//   - 漢字に挟まれた「之」「の」「ノ」は「ノ」に統一（御茶之水/御茶ノ水）
+func Variants(s string) string {
+	rs := []rune(s)
+	for i := 1; i+1 < len(rs); i++ {
+		if !isKanji(rs[i-1]) || !isKanji(rs[i+1]) {
+			continue
+		}
+		switch rs[i] {
+		case 'ヶ', 'ヵ', 'ケ', 'が', 'ガ':
+			rs[i] = 'ケ'
+		case '之', 'の', 'ノ':
+			rs[i] = 'ノ'
+		}
+	}
+	return string(rs)
+}
+
+// kanjiDigits は漢数字と数値の対応です。
+var kanjiDigits = map[rune]int{
+	'〇': 0, '零': 0, '一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+// kanjiUnits は漢数字の位取りと数値の対応です。
+var kanjiUnits = map[rune]int{'十': 10, '百': 100, '千': 1000}
+
+// IsKanjiNumeral は漢数字かどうかを判定します。
+func IsKanjiNumeral(r rune) bool {
+	_, d := kanjiDigits[r]
+	_, u := kanjiUnits[r]
+	return d || u
+}
+
+// ParseKanjiNumber は漢数字の並びを数値に変換します。
+// 「二十三」のような位取り表記と「二三」「二〇五」のような位取りなし表記の両方に対応します。
+// 漢数字以外の文字を含む場合は false を返します。
+func ParseKanjiNumber(s string) (int, bool) {
+	rs := []rune(s)
+	if len(rs) == 0 {
+		return 0, false
+	}
+	positional := true
+	for _, r := range rs {
+		if !IsKanjiNumeral(r) {
+			return 0, false
+		}
+		if _, ok := kanjiUnits[r]; ok {
+			positional = false
+		}
+	}
+
+	if positional {
+		n := 0
+		for _, r := range rs {
+			n = n*10 + kanjiDigits[r]
+		}
+		return n, true
+	}
+
+	total, current := 0, 0
+	for _, r := range rs {
+		if d, ok := kanjiDigits[r]; ok {
+			current = d
+			continue
+		}
+		if current == 0 {
+			current = 1
+		}
+		total += current * kanjiUnits[r]
+		current = 0
+	}
+	return total + current, true
+}
+
+// KanjiNumerals は文字列中の漢数字の並びをすべて半角数字に変換します。
+// 「一番町」のような地名中の漢数字も変換されるため、番地部分など数字であることが明らかな文字列に使用してください。
+func KanjiNumerals(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i := 0; i < len(rs); i++ {
+		if !IsKanjiNumeral(rs[i]) {
+			b.WriteRune(rs[i])
+			continue
+		}
+		j := i
+		for j < len(rs) && IsKanjiNumeral(rs[j]) {
+			j++
+		}
+		n, _ := ParseKanjiNumber(string(rs[i:j]))
+		b.WriteString(strconv.Itoa(n))
+		i = j - 1
+	}
+	return b.String()
+}
+
+// BlockNumerals は丁目・番地・号などの表記が直後に続く漢数字のみを半角数字に変換します。
+// 「二番地の三」「1-三」のように数字と番地表記の後に続き、直後に漢字が続かない漢数字も変換します。
+// 「千代田」「1番三井」のような地名・建物名中の漢数字は変換しません。
+func BlockNumerals(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i := 0; i < len(rs); i++ {
+		if !IsKanjiNumeral(rs[i]) {
+			b.WriteRune(rs[i])
+			continue
+		}
+		j := i
+		for j < len(rs) && IsKanjiNumeral(rs[j]) {
+			j++
+		}
+		trailing := followsBlockNumber(b.String()) && (j == len(rs) || !isKanji(rs[j]))
+		if hasChomeMarker(string(rs[j:])) || trailing {
+			n, _ := ParseKanjiNumber(string(rs[i:j]))
+			b.WriteString(strconv.Itoa(n))
+		} else {
+			b.WriteString(string(rs[i:j]))
+		}
+		i = j - 1
+	}
+	return b.String()
+}
+
+// blockSeparators は番地の数字の間に入る表記です。
+var blockSeparators = []string{"-", "の", "ノ", "番地", "番", "丁目"}
+
+// followsBlockNumber は文字列が「2番地の」「1-」のように数字と番地表記で終わるかどうかを判定します。
+func followsBlockNumber(s string) bool {
+	stripped := false
+	for {
+		trimmed := false
+		for _, sep := range blockSeparators {
+			if strings.HasSuffix(s, sep) {
+				s = strings.TrimSuffix(s, sep)
+				stripped, trimmed = true, true
+				break
+			}
+		}
+		if !trimmed {
+			break
+		}
+	}
+	rs := []rune(s)
+	return stripped && len(rs) > 0 && unicode.IsDigit(rs[len(rs)-1])
+}
+
+// chomeMarkers は数字の後に続く丁目・番地表記と、その置き換え先です。
+var chomeMarkers = []struct {
+	marker string
+	repl   string
+}{
+	{"丁目", "-"}, {"番地", "-"}, {"番", "-"}, {"号", ""}, {"の", "-"}, {"ノ", "-"},
+}
+
+// chomeMarker は文字列の先頭にある丁目・番地表記と、その置き換え先を返します。
+// 「番」は「一番町」のような地名と区別するため、直後に数字が続くか、番地の部分の末尾にある場合のみ表記とみなします。
+func chomeMarker(s string) (marker string, repl string, ok bool) {
+	for _, m := range chomeMarkers {
+		rest, found := strings.CutPrefix(s, m.marker)
+		if !found {
+			continue
+		}
+		if m.marker == "番" && !endsBlock(rest) {
+			return "", "", false
+		}
+		return m.marker, m.repl, true
+	}
+	return "", "", false
+}
+
+// endsBlock は番地表記の直後の文字列が、番地の続きまたは番地の部分の終わりかどうかを判定します。
+// 漢数字以外の漢字が続く場合は地名の一部とみなします。
+func endsBlock(rest string) bool {
+	rs := []rune(rest)
+	if len(rs) == 0 {
+		return true
+	}
+	r := rs[0]
+	return unicode.IsDigit(r) || IsKanjiNumeral(r) || isHyphen(r) || !isKanji(r)
+}
+
+// hasChomeMarker は文字列が丁目・番地表記またはハイフン類で始まるかどうかを判定します。
+func hasChomeMarker(s string) bool {
+	if strings.HasPrefix(Hyphens(s), "-") {
+		return true
+	}
+	_, _, ok := chomeMarker(s)
+	return ok
+}
+
+// Chome は「1丁目9番1号」「1-9-1」のような丁目・番地の表記を「1-9-1」の形式に統一します。
+// 数字の直後にある表記のみを対象とし、「番地の」のように続く表記はまとめて置き換えます。
+func Chome(s string) string {
+	rs := []rune(Hyphens(s))
+	var b strings.Builder
+	for i := 0; i < len(rs); i++ {
+		b.WriteRune(rs[i])
+		if !unicode.IsDigit(rs[i]) || i+1 < len(rs) && unicode.IsDigit(rs[i+1]) {
+			continue
+		}
+		for {
+			marker, repl, ok := chomeMarker(string(rs[i+1:]))
+			if !ok {
+				break
+			}
+			b.WriteString(repl)
+			i += len([]rune(marker))
+		}
+	}
+	out := b.String()
+	for strings.Contains(out, "--") {
+		out = strings.ReplaceAll(out, "--", "-")
+	}
+	return strings.TrimSuffix(out, "-")
+}
+
+// Key は住所の比較に用いるキー文字列を返します。
+// NFKC 正規化、空白の除去、旧字体・表記揺れの統一、丁目・番地の漢数字の変換、丁目表記の統一をすべて適用します。
+// 2つの文字列の Key が等しければ、同じ住所表記とみなせます。
+func Key(s string) string {
+	s = strings.Join(strings.Fields(NFKC(s)), "")
+	s = OldKanji(s)
+	s = Variants(s)
+	s = BlockNumerals(s)
+	return Chome(s)
+}
+
+// Equal は2つの住所文字列が正規化後に等しいかどうかを判定します。
+func Equal(a, b string) bool {
+	return Key(a) == Key(b)
+}
@@ -0,0 +1,103 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b/normalize"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    func(string) string
+		input string
+		want  string
+	}{
+		{name: "NFKC full-width digits", fn: normalize.NFKC, input: "１２３ＡＢＣ", want: "123ABC"},
+		{name: "NFKC half-width kana", fn: normalize.NFKC, input: "ﾁﾖﾀﾞｸ", want: "チヨダク"},
+		{name: "Katakana from hiragana", fn: normalize.Katakana, input: "ちよだ", want: "チヨダ"},
+		{name: "Katakana from half-width", fn: normalize.Katakana, input: "ﾄｳｷｮｳﾄ", want: "トウキョウト"},
+		{name: "Hyphens", fn: normalize.Hyphens, input: "1−2―3ー4", want: "1-2-3-4"},
+		{name: "Hyphens keeps long vowel", fn: normalize.Hyphens, input: "センター", want: "センター"},
+		{name: "Input", fn: normalize.Input, input: "　千代田 　ﾋﾞﾙ ", want: "千代田 ビル"},
+		{name: "OldKanji", fn: normalize.OldKanji, input: "長濱市高﨑", want: "長浜市高崎"},
+		{name: "Variants ke", fn: normalize.Variants, input: "霞が関", want: "霞ケ関"},
+		{name: "Variants ke small", fn: normalize.Variants, input: "霞ヶ関", want: "霞ケ関"},
+		{name: "Variants no", fn: normalize.Variants, input: "御茶之水", want: "御茶ノ水"},
+		{name: "Variants ignores kana words", fn: normalize.Variants, input: "ひばりが丘", want: "ひばりが丘"},
+		{name: "KanjiNumerals", fn: normalize.KanjiNumerals, input: "二十三番地の二〇五", want: "23番地の205"},
+		{name: "BlockNumerals", fn: normalize.BlockNumerals, input: "千代田一丁目二番", want: "千代田1丁目2番"},
+		{name: "BlockNumerals trailing", fn: normalize.BlockNumerals, input: "本町二番地の三", want: "本町2番地の3"},
+		{name: "BlockNumerals keeps building", fn: normalize.BlockNumerals, input: "1-1千代田ビル三階", want: "1-1千代田ビル三階"},
+		{name: "BlockNumerals keeps name after block", fn: normalize.BlockNumerals, input: "1番三井ビル", want: "1番三井ビル"},
+		{name: "Chome", fn: normalize.Chome, input: "1丁目9番1号", want: "1-9-1"},
+		{name: "Chome hyphen", fn: normalize.Chome, input: "1－9－1", want: "1-9-1"},
+		{name: "Key", fn: normalize.Key, input: "丸の内　一丁目９番地１号", want: "丸ノ内1-9-1"},
+		{name: "Key ichibancho", fn: normalize.Key, input: "一番町", want: "一番町"},
+		{name: "Key nibancho", fn: normalize.Key, input: "二番町10", want: "二番町10"},
+		{name: "Key sanbancho", fn: normalize.Key, input: "三番町 五番地", want: "三番町5"},
+		{name: "Key ban at end", fn: normalize.Key, input: "大手町二十三番", want: "大手町23"},
+		{name: "Key banchi no", fn: normalize.Key, input: "本町一丁目二番地の三", want: "本町1-2-3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.fn(tt.input))
+		})
+	}
+}
+
+func TestParseKanjiNumber(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   int
+		wantOK bool
+	}{
+		{input: "一", want: 1, wantOK: true},
+		{input: "十", want: 10, wantOK: true},
+		{input: "二十三", want: 23, wantOK: true},
+		{input: "百五", want: 105, wantOK: true},
+		{input: "千二百", want: 1200, wantOK: true},
+		{input: "二〇五", want: 205, wantOK: true},
+		{input: "", wantOK: false},
+		{input: "一番", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, ok := normalize.ParseKanjiNumber(tt.input)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "霞が関", b: "霞ヶ関", want: true},
+		{a: "千代田区 ", b: "千代田区", want: true},
+		{a: "大手町１－１", b: "大手町一丁目一番", want: true},
+		{a: "澤田", b: "沢田", want: true},
+		{a: "千代田", b: "中央", want: false},
+		{a: "一番町", b: "一番町", want: true},
+		{a: "二番町１０", b: "二番町10", want: true},
+		{a: "三番町五番一号", b: "三番町5-1", want: true},
+		{a: "一番町", b: "1-町", want: false},
+		{a: "本町一丁目二番地の三", b: "本町1-2-3", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, normalize.Equal(tt.a, tt.b))
+		})
+	}
+}
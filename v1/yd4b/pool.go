@@ -0,0 +1,242 @@
+package yd4b
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// PoolStrategy は [Pool] がリクエストを送信するクライアントを選ぶ方法です。
+type PoolStrategy int
+
+const (
+	PoolRoundRobin PoolStrategy = iota // 順番に選ぶ
+	PoolLeastUsed                      // 実行中のリクエストが最も少ないクライアントを選ぶ
+)
+
+// PoolMemberStats は [Pool] に含まれる1つのクライアントの統計情報です。
+type PoolMemberStats struct {
+	ClientID       string    // クライアントID
+	Requests       uint64    // 送信したリクエストの数
+	Failures       uint64    // エラーになったリクエストの数
+	Failovers      uint64    // 401、403、429 などにより他のクライアントに切り替えた回数
+	InFlight       int       // 実行中のリクエストの数
+	TokenValid     bool      // API利用トークンが有効かどうか
+	TokenExpiresAt time.Time // API利用トークンの有効期限（不明な場合はゼロ値）
+	CooldownUntil  time.Time // 429 の Retry-After により選ばれなくなる期限（ない場合はゼロ値）
+}
+
+// poolMember は Pool に含まれるクライアントと、その統計情報です。
+type poolMember struct {
+	client    *Client
+	requests  uint64
+	failures  uint64
+	failovers uint64
+	inFlight  int
+	cooldown  time.Time
+	tokenMu   sync.Mutex // トークンの取得を1回にまとめる
+}
+
+// Pool は異なる認証情報を持つ複数の [Client] に負荷を分散するクライアントです。
+// あるクライアントで 401、403、429 のエラーやトークンの取得失敗が発生した場合は、他のクライアントで再試行します。
+type Pool struct {
+	mu       sync.Mutex
+	members  []*poolMember
+	strategy PoolStrategy
+	next     int
+}
+
+// NewPool は新しい Pool を生成します。
+// 各クライアントのトークンは Pool が管理し、未取得または期限切れの場合はリクエストの前に取得します。
+//
+// 引数:
+//   - strategy: クライアントを選ぶ方法
+//   - clients: 負荷を分散するクライアント（それぞれ異なる認証情報と送信元IPアドレスを設定したもの）
+//
+// 戻り値:
+//   - *Pool: 生成された Pool
+func NewPool(strategy PoolStrategy, clients ...*Client) *Pool {
+	p := &Pool{strategy: strategy}
+	for _, c := range clients {
+		p.members = append(p.members, &poolMember{client: c})
+	}
+	return p
+}
+
+// Stats は各クライアントの統計情報を NewPool に渡した順に返します。
+func (p *Pool) Stats() []PoolMemberStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	stats := make([]PoolMemberStats, len(p.members))
+	for i, m := range p.members {
+		expires, _ := m.client.TokenExpiresAt()
+		stats[i] = PoolMemberStats{
+			ClientID:       m.client.ClientID(),
+			Requests:       m.requests,
+			Failures:       m.failures,
+			Failovers:      m.failovers,
+			InFlight:       m.inFlight,
+			TokenValid:     m.client.TokenValid(now),
+			TokenExpiresAt: expires,
+		}
+		if now.Before(m.cooldown) {
+			stats[i].CooldownUntil = m.cooldown
+		}
+	}
+	return stats
+}
+
+// Searchcode は [Client.Searchcode] を Pool のいずれかのクライアントで実行します。
+func (p *Pool) Searchcode(code string, opts ...searchcodeOption) (SearchcodeResponse, error) {
+	return p.SearchcodeContext(context.Background(), code, opts...)
+}
+
+// SearchcodeContext は [Client.SearchcodeContext] を Pool のいずれかのクライアントで実行します。
+func (p *Pool) SearchcodeContext(ctx context.Context, code string, opts ...searchcodeOption) (SearchcodeResponse, error) {
+	return poolDo(ctx, p, func(c *Client) (SearchcodeResponse, error) {
+		return c.SearchcodeContext(ctx, code, opts...)
+	})
+}
+
+// AddressZip は [Client.AddressZip] を Pool のいずれかのクライアントで実行します。
+func (p *Pool) AddressZip(opts ...addressRequestOption) (AddressResponse, error) {
+	return p.AddressZipContext(context.Background(), opts...)
+}
+
+// AddressZipContext は [Client.AddressZipContext] を Pool のいずれかのクライアントで実行します。
+func (p *Pool) AddressZipContext(ctx context.Context, opts ...addressRequestOption) (AddressResponse, error) {
+	return poolDo(ctx, p, func(c *Client) (AddressResponse, error) {
+		return c.AddressZipContext(ctx, opts...)
+	})
+}
+
+// order はリクエストを試すクライアントの順番を返します。
+// 429 の Retry-After による待機中のクライアントは後回しにします。
+func (p *Pool) order() []*poolMember {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	members := slices.Clone(p.members)
+	switch p.strategy {
+	case PoolLeastUsed:
+		slices.SortStableFunc(members, func(a, b *poolMember) int {
+			if a.inFlight != b.inFlight {
+				return a.inFlight - b.inFlight
+			}
+			return int(a.requests) - int(b.requests)
+		})
+	default:
+		if len(members) > 0 {
+			start := p.next % len(members)
+			members = append(members[start:], members[:start]...)
+			p.next++
+		}
+	}
+	now := time.Now()
+	slices.SortStableFunc(members, func(a, b *poolMember) int {
+		ca, cb := now.Before(a.cooldown), now.Before(b.cooldown)
+		switch {
+		case ca == cb:
+			return 0
+		case cb:
+			return -1
+		}
+		return 1
+	})
+	return members
+}
+
+// failoverable はエラーが他のクライアントで再試行すべきものかどうかを返します。
+func failoverable(err error) bool {
+	var yerr *Error
+	if !errors.As(err, &yerr) {
+		return false
+	}
+	switch yerr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
+
+// ensureToken はクライアントのトークンが有効でなければ取得します。
+func (p *Pool) ensureToken(ctx context.Context, m *poolMember) error {
+	m.tokenMu.Lock()
+	defer m.tokenMu.Unlock()
+	if m.client.TokenValid(time.Now()) {
+		return nil
+	}
+	res, err := m.client.GetTokenContext(ctx)
+	if err != nil {
+		return err
+	}
+	m.client.SetTokenResponse(res)
+	return nil
+}
+
+// poolDo は Pool のクライアントを順に試し、最初に成功した結果を返します。
+// 401、403、429 のエラーまたはトークンの取得に失敗した場合は次のクライアントで再試行し、すべて失敗した場合は最後のエラーを返します。
+func poolDo[T any](ctx context.Context, p *Pool, fn func(c *Client) (T, error)) (res T, err error) {
+	members := p.order()
+	if len(members) == 0 {
+		err = NewError(500, "pool has no clients")
+		return
+	}
+
+	for i, m := range members {
+		p.mu.Lock()
+		m.requests++
+		m.inFlight++
+		p.mu.Unlock()
+
+		tokenErr := p.ensureToken(ctx, m)
+		if tokenErr == nil {
+			res, err = fn(m.client)
+		} else {
+			err = tokenErr
+		}
+
+		p.mu.Lock()
+		m.inFlight--
+		if err != nil {
+			m.failures++
+		}
+		retry := err != nil && ctx.Err() == nil && (tokenErr != nil || failoverable(err)) && i < len(members)-1
+		if retry {
+			m.failovers++
+		}
+		p.mu.Unlock()
+
+		if err == nil {
+			return
+		}
+		p.penalize(m, err)
+		if !retry {
+			return
+		}
+	}
+	return
+}
+
+// penalize はエラーに応じてクライアントの状態を更新します。
+// 401 の場合はトークンを破棄して次回取得し直し、429 の場合は Retry-After の間選ばれにくくします。
+func (p *Pool) penalize(m *poolMember, err error) {
+	var yerr *Error
+	if !errors.As(err, &yerr) {
+		return
+	}
+	switch yerr.StatusCode {
+	case http.StatusUnauthorized:
+		m.client.SetToken("")
+	case http.StatusTooManyRequests:
+		if s, ok := m.client.RateLimitStatus(); ok && !s.RetryAt().IsZero() {
+			p.mu.Lock()
+			m.cooldown = s.RetryAt()
+			p.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// poolClient はトークン取得には常に成功し、検索には status を返すクライアントです。
+type poolClient struct {
+	*yd4b.Client
+	status  atomic.Int32
+	header  http.Header
+	tokens  atomic.Int32
+	lookups atomic.Int32
+}
+
+func newPoolClient(id string, status int) *poolClient {
+	pc := &poolClient{Client: yd4b.NewClient("https://api.example.com", id, "secret", "1.2.3.4")}
+	pc.status.Store(int32(status))
+	pc.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/j/token") {
+			pc.tokens.Add(1)
+			body := fmt.Sprintf(`{"scope":"J1","token_type":"Bearer","expires_in":600,"token":"token-%s"}`, id)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		}
+		pc.lookups.Add(1)
+		if got := req.Header.Get("Authorization"); got != "Bearer token-"+id {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+		}
+		body := fmt.Sprintf(`{"searchtype":"%s"}`, id)
+		return &http.Response{StatusCode: int(pc.status.Load()), Header: pc.header, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	})
+	return pc
+}
+
+func TestPool_Strategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy yd4b.PoolStrategy
+	}{
+		{name: "round robin", strategy: yd4b.PoolRoundRobin},
+		{name: "least used", strategy: yd4b.PoolLeastUsed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b, c := newPoolClient("a", 200), newPoolClient("b", 200), newPoolClient("c", 200)
+			pool := yd4b.NewPool(tt.strategy, a.Client, b.Client, c.Client)
+
+			var used []string
+			for range 6 {
+				res, err := pool.Searchcode("1000001")
+				require.NoError(t, err)
+				used = append(used, res.Searchtype)
+			}
+			assert.Equal(t, []string{"a", "b", "c", "a", "b", "c"}, used)
+
+			// トークンはクライアントごとに1回だけ取得する
+			for _, pc := range []*poolClient{a, b, c} {
+				assert.Equal(t, int32(1), pc.tokens.Load())
+			}
+			for _, s := range pool.Stats() {
+				assert.Equal(t, uint64(2), s.Requests)
+				assert.True(t, s.TokenValid)
+				assert.False(t, s.TokenExpiresAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestPool_Failover(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantFailover bool
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, wantFailover: true},
+		{name: "forbidden", status: http.StatusForbidden, wantFailover: true},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantFailover: true},
+		{name: "not found", status: http.StatusNotFound},
+		{name: "server error", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := newPoolClient("a", tt.status), newPoolClient("b", 200)
+			pool := yd4b.NewPool(yd4b.PoolRoundRobin, a.Client, b.Client)
+
+			_, err := pool.AddressZip(yd4b.WithPrefCode("13"))
+			stats := pool.Stats()
+			assert.Equal(t, "a", stats[0].ClientID)
+			assert.Equal(t, uint64(1), stats[0].Failures)
+			if !tt.wantFailover {
+				var yerr *yd4b.Error
+				require.ErrorAs(t, err, &yerr)
+				assert.Equal(t, tt.status, yerr.StatusCode)
+				assert.Zero(t, stats[1].Requests, "errors other than 401/403/429 are returned as is")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(1), b.lookups.Load())
+			assert.Equal(t, uint64(1), stats[0].Failovers)
+			assert.Equal(t, uint64(1), stats[1].Requests)
+			assert.Zero(t, stats[1].Failures)
+			if tt.status == http.StatusUnauthorized {
+				assert.False(t, stats[0].TokenValid, "token is discarded after 401")
+			}
+		})
+	}
+}
+
+func TestPool_AllFail(t *testing.T) {
+	t.Parallel()
+
+	a, b := newPoolClient("a", http.StatusTooManyRequests), newPoolClient("b", http.StatusForbidden)
+	pool := yd4b.NewPool(yd4b.PoolRoundRobin, a.Client, b.Client)
+
+	_, err := pool.Searchcode("1000001")
+	var yerr *yd4b.Error
+	require.ErrorAs(t, err, &yerr)
+	assert.Equal(t, http.StatusForbidden, yerr.StatusCode, "returns the last error")
+	assert.Equal(t, int32(1), a.lookups.Load())
+	assert.Equal(t, int32(1), b.lookups.Load())
+}
+
+func TestPool_Cooldown(t *testing.T) {
+	t.Parallel()
+
+	a, b := newPoolClient("a", http.StatusTooManyRequests), newPoolClient("b", 200)
+	a.header = http.Header{"Retry-After": {"60"}}
+	pool := yd4b.NewPool(yd4b.PoolRoundRobin, a.Client, b.Client)
+
+	_, err := pool.Searchcode("1000001")
+	require.NoError(t, err)
+	assert.False(t, pool.Stats()[0].CooldownUntil.IsZero())
+
+	// Retry-After の間は a を後回しにする
+	a.status.Store(200)
+	for range 3 {
+		res, err := pool.Searchcode("1000001")
+		require.NoError(t, err)
+		assert.Equal(t, "b", res.Searchtype)
+	}
+	assert.Equal(t, int32(1), a.lookups.Load())
+}
+
+func TestPool_Empty(t *testing.T) {
+	t.Parallel()
+
+	_, err := yd4b.NewPool(yd4b.PoolRoundRobin).Searchcode("1000001")
+	assert.ErrorContains(t, err, "pool has no clients")
+}
@@ -0,0 +1,150 @@
+package yd4b
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitStatus はレスポンスヘッダから読み取ったレート制限の状態です。
+// X-RateLimit-Limit / X-RateLimit-Remaining / X-RateLimit-Reset（X- のない RateLimit-* も可）と Retry-After に対応しています。
+type RateLimitStatus struct {
+	Limit      int           // 期間内に送信できるリクエスト数（不明な場合は -1）
+	Remaining  int           // 残りのリクエスト数（不明な場合は -1）
+	Reset      time.Time     // Remaining がリセットされる時刻（不明な場合はゼロ値）
+	RetryAfter time.Duration // Retry-After ヘッダで指定された待機時間（指定がない場合は 0）
+	ObservedAt time.Time     // レスポンスを受信した時刻
+}
+
+// RetryAt は Retry-After ヘッダに従って次のリクエストを送信できる時刻を返します。指定がない場合はゼロ値を返します。
+func (s RateLimitStatus) RetryAt() time.Time {
+	if s.RetryAfter <= 0 {
+		return time.Time{}
+	}
+	return s.ObservedAt.Add(s.RetryAfter)
+}
+
+// ThrottlePolicy は [Client.SetAdaptiveThrottle] の設定です。
+type ThrottlePolicy struct {
+	Reserve  int           // 残りのリクエスト数がこの値以下になったら送信間隔を空ける（0 の場合は 10）
+	MaxDelay time.Duration // 1リクエストあたりの最大待機時間（0 の場合は無制限）
+}
+
+// parseRateLimit はレスポンスヘッダからレート制限の状態を読み取ります。該当するヘッダがない場合は false を返します。
+func parseRateLimit(h http.Header, now time.Time) (s RateLimitStatus, ok bool) {
+	s = RateLimitStatus{Limit: -1, Remaining: -1, ObservedAt: now}
+	if v, found := rateLimitHeader(h, "Limit"); found {
+		s.Limit, ok = v, true
+	}
+	if v, found := rateLimitHeader(h, "Remaining"); found {
+		s.Remaining, ok = v, true
+	}
+	if v, found := rateLimitHeader(h, "Reset"); found {
+		// 大きな値は UNIX 時刻、それ以外はリセットまでの秒数とみなす
+		if v > 1_000_000_000 {
+			s.Reset = time.Unix(int64(v), 0)
+		} else {
+			s.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+		ok = true
+	}
+	if v := h.Get("Retry-After"); v != "" {
+		if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+			s.RetryAfter, ok = time.Duration(sec)*time.Second, true
+		} else if t, err := http.ParseTime(v); err == nil {
+			s.RetryAfter, ok = max(t.Sub(now), 0), true
+		}
+	}
+	return
+}
+
+// rateLimitHeader は X-RateLimit-<name> または RateLimit-<name> ヘッダの値を返します。
+func rateLimitHeader(h http.Header, name string) (int, bool) {
+	for _, key := range []string{"X-RateLimit-" + name, "RateLimit-" + name} {
+		if v, err := strconv.Atoi(h.Get(key)); err == nil && v >= 0 {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// RateLimitStatus は最後に受信したレスポンスのレート制限の状態を返します。
+// レート制限に関するヘッダを含むレスポンスをまだ受信していない場合は false を返します。
+func (c *Client) RateLimitStatus() (RateLimitStatus, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.rateLimit, c.rateLimitSeen
+}
+
+// SetAdaptiveThrottle はレート制限に応じてリクエストの送信を遅らせるかどうかを設定します。
+// 残りのリクエスト数が policy.Reserve 以下になると、リセットまでの時間を残りのリクエスト数で割った間隔で送信し、
+// 0 になった場合や Retry-After が指定された場合はその時刻まで待機します。nil を指定すると無効になります。
+func (c *Client) SetAdaptiveThrottle(policy *ThrottlePolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if policy == nil {
+		c.throttle = nil
+		return
+	}
+	p := *policy
+	if p.Reserve <= 0 {
+		p.Reserve = 10
+	}
+	c.throttle = &p
+}
+
+// observeRateLimit はレスポンスヘッダからレート制限の状態を記録します。
+func (c *Client) observeRateLimit(h http.Header) {
+	s, ok := parseRateLimit(h, time.Now())
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit, c.rateLimitSeen = s, true
+	c.rateLimitBudget = s.Remaining
+}
+
+// throttleDelay は次のリクエストを送信するまでの待機時間を返します。
+// 同時に送信されるリクエストの間隔も空くように、待機するたびに残りのリクエスト数の見積もりを減らします。
+func (c *Client) throttleDelay(now time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, s := c.throttle, c.rateLimit
+	if p == nil || !c.rateLimitSeen {
+		return 0
+	}
+
+	var d time.Duration
+	switch {
+	case now.Before(s.RetryAt()):
+		d = s.RetryAt().Sub(now)
+	case c.rateLimitBudget < 0 || s.Reset.IsZero() || !now.Before(s.Reset):
+		return 0
+	case c.rateLimitBudget == 0:
+		d = s.Reset.Sub(now)
+	case c.rateLimitBudget <= p.Reserve:
+		d = s.Reset.Sub(now) / time.Duration(c.rateLimitBudget+1)
+		c.rateLimitBudget--
+	default:
+		c.rateLimitBudget--
+		return 0
+	}
+	if p.MaxDelay > 0 {
+		d = min(d, p.MaxDelay)
+	}
+	return d
+}
+
+// sleepContext は d だけ待機します。待機中にコンテキストがキャンセルされた場合はそのエラーを返します。
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
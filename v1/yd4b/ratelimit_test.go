@@ -0,0 +1,213 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitedDoFunc は呼び出しごとに headers の次の要素をレスポンスヘッダとして返す doFunc です。
+func rateLimitedDoFunc(headers ...http.Header) func(req *http.Request) (*http.Response, error) {
+	var mu sync.Mutex
+	i := 0
+	return func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		h := headers[min(i, len(headers)-1)]
+		i++
+		return &http.Response{StatusCode: http.StatusOK, Header: h, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	}
+}
+
+func TestClient_RateLimitStatus(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   yd4b.RateLimitStatus
+		wantOK bool
+	}{
+		{name: "no headers", header: http.Header{}},
+		{
+			name:   "x-ratelimit with delta reset",
+			header: http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"42"}, "X-Ratelimit-Reset": {"60"}},
+			want:   yd4b.RateLimitStatus{Limit: 100, Remaining: 42},
+			wantOK: true,
+		},
+		{
+			name:   "ratelimit with unix reset",
+			header: http.Header{"Ratelimit-Remaining": {"5"}, "Ratelimit-Reset": {strconv.FormatInt(reset.Unix(), 10)}},
+			want:   yd4b.RateLimitStatus{Limit: -1, Remaining: 5, Reset: reset},
+			wantOK: true,
+		},
+		{
+			name:   "retry-after seconds",
+			header: http.Header{"Retry-After": {"30"}},
+			want:   yd4b.RateLimitStatus{Limit: -1, Remaining: -1, RetryAfter: 30 * time.Second},
+			wantOK: true,
+		},
+		{name: "invalid values", header: http.Header{"X-Ratelimit-Remaining": {"many"}, "Retry-After": {"soon"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(rateLimitedDoFunc(tt.header))
+			_, err := client.Searchcode("1000001")
+			require.NoError(t, err)
+
+			got, ok := client.RateLimitStatus()
+			assert.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.WithinDuration(t, time.Now(), got.ObservedAt, time.Second)
+			if tt.header.Get("X-Ratelimit-Reset") == "60" {
+				assert.WithinDuration(t, got.ObservedAt.Add(time.Minute), got.Reset, time.Millisecond)
+				got.Reset = time.Time{}
+			}
+			got.ObservedAt = time.Time{}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_RateLimitStatus_Meta(t *testing.T) {
+	t.Parallel()
+
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(rateLimitedDoFunc(http.Header{"X-Ratelimit-Remaining": {"7"}}))
+	_, meta, err := client.SearchcodeWithMeta(context.Background(), "1000001")
+	require.NoError(t, err)
+	assert.Equal(t, 7, meta.RateLimit.Remaining)
+}
+
+func TestClient_AdaptiveThrottle(t *testing.T) {
+	resetIn := func(d time.Duration) string { return strconv.Itoa(int(d / time.Second)) }
+	tests := []struct {
+		name      string
+		policy    *yd4b.ThrottlePolicy
+		header    http.Header
+		wantDelay func(t *testing.T, d time.Duration)
+	}{
+		{
+			name:      "disabled",
+			header:    http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {resetIn(time.Minute)}},
+			wantDelay: func(t *testing.T, d time.Duration) { assert.Zero(t, d) },
+		},
+		{
+			name:      "plenty remaining",
+			policy:    &yd4b.ThrottlePolicy{},
+			header:    http.Header{"X-Ratelimit-Remaining": {"50"}, "X-Ratelimit-Reset": {resetIn(time.Minute)}},
+			wantDelay: func(t *testing.T, d time.Duration) { assert.Zero(t, d) },
+		},
+		{
+			name:   "spread remaining until reset",
+			policy: &yd4b.ThrottlePolicy{Reserve: 5},
+			header: http.Header{"X-Ratelimit-Remaining": {"3"}, "X-Ratelimit-Reset": {resetIn(time.Minute)}},
+			wantDelay: func(t *testing.T, d time.Duration) {
+				assert.InDelta(t, float64(15*time.Second), float64(d), float64(time.Second))
+			},
+		},
+		{
+			name:   "exhausted waits until reset",
+			policy: &yd4b.ThrottlePolicy{},
+			header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {resetIn(time.Minute)}},
+			wantDelay: func(t *testing.T, d time.Duration) {
+				assert.InDelta(t, float64(time.Minute), float64(d), float64(time.Second))
+			},
+		},
+		{
+			name:   "retry-after",
+			policy: &yd4b.ThrottlePolicy{},
+			header: http.Header{"Retry-After": {"20"}},
+			wantDelay: func(t *testing.T, d time.Duration) {
+				assert.InDelta(t, float64(20*time.Second), float64(d), float64(time.Second))
+			},
+		},
+		{
+			name:      "max delay",
+			policy:    &yd4b.ThrottlePolicy{MaxDelay: 2 * time.Second},
+			header:    http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {resetIn(time.Minute)}},
+			wantDelay: func(t *testing.T, d time.Duration) { assert.Equal(t, 2*time.Second, d) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(rateLimitedDoFunc(tt.header))
+			client.SetAdaptiveThrottle(tt.policy)
+			var slept time.Duration
+			yd4b.SetSleep(client, func(ctx context.Context, d time.Duration) error {
+				slept = d
+				return nil
+			})
+
+			// 1回目のレスポンスでレート制限の状態を受け取り、2回目の送信前に待機する
+			for range 2 {
+				_, err := client.Searchcode("1000001")
+				require.NoError(t, err)
+			}
+			tt.wantDelay(t, slept)
+		})
+	}
+}
+
+func TestClient_AdaptiveThrottle_Budget(t *testing.T) {
+	t.Parallel()
+
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(rateLimitedDoFunc(http.Header{"X-Ratelimit-Remaining": {"3"}, "X-Ratelimit-Reset": {"60"}}))
+	client.SetAdaptiveThrottle(&yd4b.ThrottlePolicy{})
+	var mu sync.Mutex
+	var delays []time.Duration
+	yd4b.SetSleep(client, func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delays = append(delays, d)
+		return nil
+	})
+	_, err := client.Searchcode("1000001")
+	require.NoError(t, err)
+
+	// レート制限のヘッダが返らなくても、送信したリクエストの分だけ見積もりを減らして間隔を広げる
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	})
+	for range 3 {
+		_, err := client.Searchcode("1000001")
+		require.NoError(t, err)
+	}
+	require.Len(t, delays, 3)
+	assert.Less(t, delays[0], delays[1])
+	assert.Less(t, delays[1], delays[2])
+}
+
+func TestClient_AdaptiveThrottle_Canceled(t *testing.T) {
+	t.Parallel()
+
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(rateLimitedDoFunc(http.Header{"Retry-After": {"3600"}}))
+	client.SetAdaptiveThrottle(&yd4b.ThrottlePolicy{})
+	_, err := client.Searchcode("1000001")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.SearchcodeContext(ctx, "1000001")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -2,6 +2,7 @@ package yd4b_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -148,3 +149,19 @@ func TestClient_Searchcode(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_SearchcodeContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := yd4b.NewClient("https://api.test", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	})
+
+	_, err := client.SearchcodeContext(ctx, "CODE")
+	assert.ErrorContains(t, err, "client do error")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,125 @@
+package yd4b
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+)
+
+// NewClientFromEnv が参照する環境変数の名前です。
+const (
+	EnvOrigin           = "YD4B_ORIGIN"             // APIサーバのオリジン（必須）
+	EnvClientID         = "YD4B_CLIENT_ID"          // クライアントID（必須）
+	EnvClientSecret     = "YD4B_CLIENT_SECRET"      // クライアントシークレット（EnvClientSecretFile と排他）
+	EnvClientSecretFile = "YD4B_CLIENT_SECRET_FILE" // クライアントシークレットを格納したファイルのパス（EnvClientSecret と排他）
+	EnvMyIP             = "YD4B_MYIP"               // クライアントのグローバルIPアドレス（必須、"auto" の場合は DefaultIPResolverURL で検出）
+	EnvECUID            = "YD4B_ECUID"              // プロバイダーのユーザーID（任意）
+)
+
+// SecretProvider はトークン取得時にクライアントシークレットを提供するインターフェースです。
+// トークンを取得するたびに呼び出されるため、クライアントを作り直さずにシークレットをローテーションできます。
+type SecretProvider interface {
+	// Secret は現在のクライアントシークレットを返します。
+	Secret(ctx context.Context) (string, error)
+}
+
+// StaticSecret は固定のクライアントシークレットを提供する [SecretProvider] です。
+type StaticSecret string
+
+// Secret はクライアントシークレットを返します。
+func (s StaticSecret) Secret(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// EnvSecret は環境変数からクライアントシークレットを読み込む [SecretProvider] です。値は環境変数の名前です。
+type EnvSecret string
+
+// Secret は環境変数の値を返します。環境変数が設定されていない場合はエラーを返します。
+func (s EnvSecret) Secret(ctx context.Context) (string, error) {
+	v, ok := os.LookupEnv(string(s))
+	if !ok || v == "" {
+		return "", NewError(500, string(s)+" is not set")
+	}
+	return v, nil
+}
+
+// FileSecret はファイルからクライアントシークレットを読み込む [SecretProvider] です。値はファイルのパスです。
+// Kubernetes の Secret のようにマウントされたファイルを想定しており、呼び出しのたびに読み込み直し、前後の空白を取り除きます。
+type FileSecret string
+
+// Secret はファイルの内容を返します。
+func (s FileSecret) Secret(ctx context.Context) (string, error) {
+	b, err := os.ReadFile(string(s))
+	if err != nil {
+		return "", errors.Join(NewError(500, "secret file read error"), err)
+	}
+	secret := strings.TrimSpace(string(b))
+	if secret == "" {
+		return "", NewError(500, "secret file is empty")
+	}
+	return secret, nil
+}
+
+// SetSecretProvider はクライアントシークレットの提供元を設定します。
+// 設定すると [NewClient] に渡したクライアントシークレットの代わりに使われます。nil を指定すると無効になります。
+func (c *Client) SetSecretProvider(p SecretProvider) {
+	c.secretProvider = p
+}
+
+// secret はトークン取得に使うクライアントシークレットを返します。
+func (c *Client) secret(ctx context.Context) (string, error) {
+	if c.secretProvider == nil {
+		return c.clientSecret, nil
+	}
+	return c.secretProvider.Secret(ctx)
+}
+
+// NewClientFromEnv は環境変数から設定を読み込んで [Client] を生成します。
+// 参照する環境変数は EnvOrigin などの定数を参照してください。
+// EnvClientSecretFile を指定した場合はトークン取得のたびにファイルを読み込むため、マウントされたシークレットの更新に追従します。
+//
+// 戻り値:
+//   - *Client: 生成されたクライアント
+//   - error: 必須の環境変数が設定されていない場合などのエラー
+func NewClientFromEnv() (*Client, error) {
+	return newClientFromEnv(os.Getenv)
+}
+
+// newClientFromEnv は getenv を使って [NewClientFromEnv] を実行します。
+func newClientFromEnv(getenv func(string) string) (*Client, error) {
+	for _, name := range []string{EnvOrigin, EnvClientID, EnvMyIP} {
+		if getenv(name) == "" {
+			return nil, NewError(500, name+" is not set")
+		}
+	}
+
+	secret, secretFile := getenv(EnvClientSecret), getenv(EnvClientSecretFile)
+	switch {
+	case secret != "" && secretFile != "":
+		return nil, NewError(500, EnvClientSecret+" and "+EnvClientSecretFile+" are mutually exclusive")
+	case secret == "" && secretFile == "":
+		return nil, NewError(500, EnvClientSecret+" or "+EnvClientSecretFile+" is not set")
+	}
+
+	myip := getenv(EnvMyIP)
+	if myip == "auto" {
+		myip = ""
+	} else if _, err := validateIP(myip); err != nil {
+		return nil, err
+	}
+
+	c := NewClient(getenv(EnvOrigin), getenv(EnvClientID), secret, myip)
+	c.SetECUID(getenv(EnvECUID))
+	if myip == "" {
+		c.SetIPResolver(NewHTTPIPResolver(DefaultIPResolverURL))
+	}
+	if secretFile != "" {
+		// 起動時に読み込めることを確認しておく
+		if _, err := FileSecret(secretFile).Secret(context.Background()); err != nil {
+			return nil, err
+		}
+		c.SetSecretProvider(FileSecret(secretFile))
+	}
+	return c, nil
+}
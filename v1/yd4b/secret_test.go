@@ -0,0 +1,166 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSecret(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name          string
+		content       string
+		missing       bool
+		want          string
+		wantErrSubstr string
+	}{
+		{name: "trim whitespace", content: "s3cret\n", want: "s3cret"},
+		{name: "empty", content: " \n", wantErrSubstr: "secret file is empty"},
+		{name: "missing", missing: true, wantErrSubstr: "secret file read error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(dir, tt.name)
+			if !tt.missing {
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			}
+			got, err := yd4b.FileSecret(path).Secret(context.Background())
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_SetSecretProvider(t *testing.T) {
+	t.Parallel()
+
+	// ファイルを書き換えると次のトークン取得から新しいシークレットが使われる
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+	var got []string
+	client := yd4b.NewClient("https://api.example.com", "id", "", "1.2.3.4")
+	client.SetSecretProvider(yd4b.FileSecret(path))
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		var body yd4b.TokenRequest
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		got = append(got, body.SecretKey)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"token":"t"}`))}, nil
+	})
+
+	_, err := client.GetToken()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0o600))
+	_, err = client.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old", "new"}, got)
+
+	// 提供元のエラーはトークン取得のエラーになる
+	require.NoError(t, os.Remove(path))
+	_, err = client.GetToken()
+	assert.ErrorContains(t, err, "secret file read error")
+}
+
+// secretFunc は関数を [yd4b.SecretProvider] として扱う型です。
+type secretFunc func(ctx context.Context) (string, error)
+
+func (f secretFunc) Secret(ctx context.Context) (string, error) { return f(ctx) }
+
+func TestClient_SecretProviderError(t *testing.T) {
+	t.Parallel()
+
+	errVault := errors.New("vault unavailable")
+	var calls int
+	client := yd4b.NewClient("https://api.example.com", "id", "", "1.2.3.4")
+	client.SetSecretProvider(secretFunc(func(ctx context.Context) (string, error) { return "", errVault }))
+	client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"token":"t"}`))}, nil
+	})
+
+	// 提供元のエラーは他のエラーと同様に *yd4b.Error として扱える
+	_, err := client.GetToken()
+	var yerr *yd4b.Error
+	require.ErrorAs(t, err, &yerr)
+	assert.Equal(t, http.StatusInternalServerError, yerr.StatusCode)
+	assert.Equal(t, "failed to load client secret", yerr.Message)
+	assert.ErrorIs(t, err, errVault)
+	assert.Zero(t, calls, "token endpoint is not called without a secret")
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("from-file\n"), 0o600))
+
+	base := map[string]string{
+		yd4b.EnvOrigin:       "https://api.example.com",
+		yd4b.EnvClientID:     "id",
+		yd4b.EnvClientSecret: "secret",
+		yd4b.EnvMyIP:         "1.2.3.4",
+		yd4b.EnvECUID:        "ec123",
+	}
+	tests := []struct {
+		name          string
+		override      map[string]string
+		wantSecret    string
+		wantErrSubstr string
+	}{
+		{name: "secret from env", wantSecret: "secret"},
+		{name: "secret from file", override: map[string]string{yd4b.EnvClientSecret: "", yd4b.EnvClientSecretFile: secretFile}, wantSecret: "from-file"},
+		{name: "both secrets", override: map[string]string{yd4b.EnvClientSecretFile: secretFile}, wantErrSubstr: "mutually exclusive"},
+		{name: "no secret", override: map[string]string{yd4b.EnvClientSecret: ""}, wantErrSubstr: "YD4B_CLIENT_SECRET or YD4B_CLIENT_SECRET_FILE is not set"},
+		{name: "missing secret file", override: map[string]string{yd4b.EnvClientSecret: "", yd4b.EnvClientSecretFile: secretFile + ".missing"}, wantErrSubstr: "secret file read error"},
+		{name: "no origin", override: map[string]string{yd4b.EnvOrigin: ""}, wantErrSubstr: "YD4B_ORIGIN is not set"},
+		{name: "no myip", override: map[string]string{yd4b.EnvMyIP: ""}, wantErrSubstr: "YD4B_MYIP is not set"},
+		{name: "invalid myip", override: map[string]string{yd4b.EnvMyIP: "1.2.3"}, wantErrSubstr: "not a valid IPv4 or IPv6 address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := yd4b.NewClientFromEnvFunc(func(key string) string {
+				if v, ok := tt.override[key]; ok {
+					return v
+				}
+				return base[key]
+			})
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://api.example.com", yd4b.GetOrigin(client))
+			assert.Equal(t, "ec123", yd4b.GetECUID(client))
+
+			var gotSecret string
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				var body yd4b.TokenRequest
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				gotSecret = body.SecretKey
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"token":"t"}`))}, nil
+			})
+			_, err = client.GetToken()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSecret, gotSecret)
+		})
+	}
+}
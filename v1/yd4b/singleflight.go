@@ -0,0 +1,124 @@
+package yd4b
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// flightCall は実行中の上流リクエスト1件と、その結果を待つ呼び出し元の情報です。
+type flightCall struct {
+	done    chan struct{}
+	waiters int
+	cancel  context.CancelFunc
+
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// flightGroup は同一キーの同時リクエストを1回の上流リクエストにまとめます。
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// newFlightGroup は空の flightGroup を生成します。
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// do は key に対応するリクエストが実行中であればその結果を待ち、なければ fn を実行します。
+// 上流リクエストは待機者全員がキャンセルした場合にのみキャンセルされます。
+// 結果のレスポンスは呼び出し元ごとに複製されるため、Body はそれぞれ独立して読み出せます。
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if ok {
+		call.waiters++
+	} else {
+		upstream, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.calls[key] = call
+		go g.run(upstream, key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		return &http.Response{
+			Status:     http.StatusText(call.status),
+			StatusCode: call.status,
+			Header:     call.header.Clone(),
+			Body:       io.NopCloser(bytes.NewReader(call.body)),
+		}, nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// run は上流リクエストを実行し、レスポンスボディを読み切って結果を保存します。
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(ctx context.Context) (*http.Response, error)) {
+	defer func() {
+		call.cancel()
+		g.mu.Lock()
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	resp, err := fn(ctx)
+	if err != nil {
+		call.err = err
+		return
+	}
+	defer resp.Body.Close()
+
+	call.status = resp.StatusCode
+	call.header = resp.Header
+	call.body, call.err = io.ReadAll(resp.Body)
+}
+
+// flightKey はリクエストのメソッド、URL、ボディから重複判定用のキーを生成します。
+func flightKey(req *http.Request) (string, error) {
+	key := req.Method + " " + req.URL.String()
+	if req.Body == nil || req.Body == http.NoBody {
+		return key, nil
+	}
+
+	var body []byte
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+		if body, err = io.ReadAll(rc); err != nil {
+			return "", err
+		}
+	} else {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return key + "\n" + string(body), nil
+}
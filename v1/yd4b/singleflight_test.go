@@ -0,0 +1,145 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+// gatedDoFunc は release が閉じられるまでレスポンスを返さない doFunc です。
+func gatedDoFunc(calls *int32, release <-chan struct{}, canceled chan<- struct{}) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+		select {
+		case <-release:
+		case <-req.Context().Done():
+			if canceled != nil {
+				close(canceled)
+			}
+			return nil, req.Context().Err()
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"count":1,"addresses":[{"zip_code":"1000001"}]}`)),
+		}, nil
+	}
+}
+
+func TestClient_Singleflight(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		wantCalls int32
+	}{
+		{name: "enabled", enabled: true, wantCalls: 1},
+		{name: "disabled", enabled: false, wantCalls: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			release := make(chan struct{})
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(gatedDoFunc(&calls, release, nil))
+			client.SetSingleflight(tt.enabled)
+
+			var wg sync.WaitGroup
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					res, err := client.SearchcodeContext(context.Background(), "1000001")
+					assert.NoError(t, err)
+					assert.Equal(t, "1000001", res.Addresses[0].ZipCode)
+				}()
+			}
+			assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == tt.wantCalls }, time.Second, time.Millisecond)
+			close(release)
+			wg.Wait()
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestClient_Singleflight_AddressZipKey(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	release := make(chan struct{})
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(gatedDoFunc(&calls, release, nil))
+	client.SetSingleflight(true)
+
+	var wg sync.WaitGroup
+	for _, town := range []string{"千代田", "千代田", "大手町", "大手町"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.AddressZipContext(context.Background(), yd4b.WithTownName(town))
+			assert.NoError(t, err)
+		}()
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 2 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestClient_Singleflight_Cancel(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	release := make(chan struct{})
+	canceled := make(chan struct{})
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetDoFunc(gatedDoFunc(&calls, release, canceled))
+	client.SetSingleflight(true)
+
+	// 1人の待機者がキャンセルしても、他の待機者は結果を受け取れる
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := client.SearchcodeContext(ctx1, "1000001")
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	go func() {
+		_, err := client.SearchcodeContext(ctx2, "1000001")
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel1()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	close(release)
+	assert.NoError(t, <-errs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// 全員がキャンセルすると上流リクエストもキャンセルされる
+	ctx3, cancel3 := context.WithCancel(context.Background())
+	client.SetDoFunc(gatedDoFunc(&calls, make(chan struct{}), canceled))
+	go func() {
+		_, err := client.SearchcodeContext(ctx3, "1000002")
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 2 }, time.Second, time.Millisecond)
+	cancel3()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("upstream request was not canceled")
+	}
+}
@@ -0,0 +1,133 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b/internal/apispec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tagFields は構造体のフィールドを指定したタグの名前で引けるようにします。タグのないフィールドや "-" は除外します。
+func tagFields(t reflect.Type, key string) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get(key), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// operation は operationId に対応する specification のエンドポイントを返します。
+func operation(t *testing.T, id string) apispec.Operation {
+	t.Helper()
+	i := slices.IndexFunc(apispec.Operations, func(op apispec.Operation) bool { return op.ID == id })
+	require.GreaterOrEqual(t, i, 0, "operation %s is not in the spec", id)
+	return apispec.Operations[i]
+}
+
+func TestSpecConformance_SearchcodeParams(t *testing.T) {
+	t.Parallel()
+
+	got := tagFields(reflect.TypeOf(yd4b.NewSearchcodeRequest("1000001")).Elem(), "json")
+	want := map[string]string{}
+	for _, p := range operation(t, "searchcode").Parameters {
+		// ec_uid はリクエストではなく Client が付与する
+		if p.In == "query" && p.Name != "ec_uid" {
+			want[p.Name] = p.Type
+		}
+	}
+	assert.ElementsMatch(t, slices.Collect(maps.Keys(want)), slices.Collect(maps.Keys(got)))
+	for name, typ := range want {
+		if gf, ok := got[name]; ok {
+			assert.Equal(t, typ, gf.Type.String(), "type of %q differs from the spec", name)
+		}
+	}
+}
+
+func TestSpecConformance_AddressRequestOptions(t *testing.T) {
+	options := map[string]yd4b.AddressRequestOption{
+		"pref_code":   yd4b.WithPrefCode("13"),
+		"pref_name":   yd4b.WithPrefName("東京都"),
+		"pref_kana":   yd4b.WithPrefKana("トウキョウト"),
+		"pref_roma":   yd4b.WithPrefRoma("TOKYO"),
+		"city_code":   yd4b.WithCityCode("13101"),
+		"city_name":   yd4b.WithCityName("千代田区"),
+		"city_kana":   yd4b.WithCityKana("チヨダク"),
+		"city_roma":   yd4b.WithCityRoma("CHIYODA-KU"),
+		"town_name":   yd4b.WithTownName("千代田"),
+		"town_kana":   yd4b.WithTownKana("チヨダ"),
+		"town_roma":   yd4b.WithTownRoma("CHIYODA"),
+		"freeword":    yd4b.WithFreeword("千代田"),
+		"flg_getcity": yd4b.WithFlgGetCity(1),
+		"flg_getpref": yd4b.WithFlgGetPref(1),
+		"page":        yd4b.WithAZPage(2),
+		"limit":       yd4b.WithAZLimit(10),
+	}
+
+	// リクエストボディの型は specification から生成している
+	spec := tagFields(reflect.TypeFor[yd4b.AddressRequest](), "json")
+	assert.ElementsMatch(t, slices.Collect(maps.Keys(spec)), slices.Collect(maps.Keys(options)), "every field in the spec needs an option")
+
+	for name, opt := range options {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(yd4b.NewAddressRequest(opt))
+			require.NoError(t, err)
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(b, &got))
+			assert.Len(t, got, 1)
+			assert.Contains(t, got, name)
+		})
+	}
+}
+
+func TestSpecConformance_Operations(t *testing.T) {
+	// operationId ごとに、そのエンドポイントを呼び出す Client のメソッドを対応付ける
+	calls := map[string]func(c *yd4b.Client) error{
+		"token":      func(c *yd4b.Client) error { _, err := c.GetToken(); return err },
+		"searchcode": func(c *yd4b.Client) error { _, err := c.Searchcode("1000001"); return err },
+		"addresszip": func(c *yd4b.Client) error { _, err := c.AddressZip(yd4b.WithPrefCode("13")); return err },
+	}
+
+	ids := make([]string, 0, len(apispec.Operations))
+	for _, op := range apispec.Operations {
+		ids = append(ids, op.ID)
+	}
+	assert.ElementsMatch(t, ids, slices.Collect(maps.Keys(calls)), "every operation in the spec needs a Client method")
+
+	for _, op := range apispec.Operations {
+		t.Run(op.ID, func(t *testing.T) {
+			t.Parallel()
+
+			call, ok := calls[op.ID]
+			if !ok {
+				t.Skip("not implemented")
+			}
+			var method, path string
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetToken("token")
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				method, path = req.Method, req.URL.Path
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+			})
+			require.NoError(t, call(client))
+			assert.Equal(t, op.Method, method)
+			assert.Equal(t, strings.ReplaceAll(op.Path, "{search_code}", "1000001"), path)
+		})
+	}
+}
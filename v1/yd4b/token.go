@@ -2,20 +2,13 @@ package yd4b
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
-	"net/url"
 	"strings"
 )
 
-// TokenRequest は API 利用トークン取得のためのリクエストボディです。
-type TokenRequest struct {
-	GrantType string `json:"grant_type"` // 認可タイプ（client_credentials 固定）
-	ClientID  string `json:"client_id"`  // クライアントID
-	SecretKey string `json:"secret_key"` // シークレットキー
-}
-
 // ToRequest は TokenRequest を HTTP POST リクエストに変換します。
 //
 // 引数:
@@ -36,52 +29,46 @@ func (t *TokenRequest) ToRequest(endpoint string) (req *http.Request, err error)
 	return
 }
 
-// TokenResponse はトークン取得APIのレスポンス形式です。
-type TokenResponse struct {
-	Scope     string `json:"scope"`      // トークンスコープ（例: "J1"）
-	TokenType string `json:"token_type"` // トークンタイプ（例: "Bearer"）
-	ExpiresIn int64  `json:"expires_in"` // 有効期限（秒数）
-	Token     string `json:"token"`      // アクセストークン（"Token: " プレフィックス付きの場合あり）
-}
-
 // GetToken はトークン取得APIを呼び出し、アクセストークンを取得します。
 //
 // 戻り値:
 //   - TokenResponse: トークン情報（スコープ、タイプ、有効秒数、トークン）
 //   - error: 通信エラー、ステータスコード異常、デコード失敗など
 func (c *Client) GetToken() (res TokenResponse, err error) {
-	endpoint, err := url.JoinPath(c.origin, "api", c.version, "j", "token")
-	if err != nil {
-		err = errors.Join(NewError(500, "endpoint error"), err)
-		return
-	}
+	return c.GetTokenContext(context.Background())
+}
 
-	body := &TokenRequest{
-		GrantType: "client_credentials",
-		ClientID:  c.clientID,
-		SecretKey: c.clientSecret,
-	}
-	req, err := body.ToRequest(endpoint)
-	if err != nil {
-		err = errors.Join(NewError(500, "request creation error"), err)
-		return
+// GetTokenContext はコンテキストを指定して [Client.GetToken] を実行します。
+// [Client.SetTokenStore] で保存先が設定されている場合は、保存先の有効なトークンを優先して返します。
+func (c *Client) GetTokenContext(ctx context.Context) (res TokenResponse, err error) {
+	if c.tokenStore != nil {
+		return c.getStoredToken(ctx)
 	}
+	return c.fetchToken(ctx)
+}
 
-	resp, err := c.do(req)
+// tokenEndpoint はトークン取得APIの記述子です。
+var tokenEndpoint = endpoint[*TokenRequest]{
+	name:   "token",
+	method: http.MethodPost,
+	path:   func(*TokenRequest) []string { return []string{"j", "token"} },
+	body:   true,
+}
+
+// fetchToken はトークン取得APIを呼び出します。
+func (c *Client) fetchToken(ctx context.Context) (res TokenResponse, err error) {
+	secret, err := c.secret(ctx)
 	if err != nil {
-		err = errors.Join(NewError(500, "client do error"), err)
+		err = errors.Join(NewError(500, "failed to load client secret"), err)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		err = NewError(resp.StatusCode, "unexpected status code")
-		return
+	body := &TokenRequest{
+		GrantType: "client_credentials",
+		ClientID:  c.clientID,
+		SecretKey: secret,
 	}
-
-	err = json.NewDecoder(resp.Body).Decode(&res)
-	if err != nil {
-		err = errors.Join(NewError(500, "json decoding error"), err)
+	if res, err = call[*TokenRequest, TokenResponse](ctx, c, tokenEndpoint, body); err != nil {
 		return
 	}
 
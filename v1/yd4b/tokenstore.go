@@ -0,0 +1,356 @@
+package yd4b
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin は保存されたトークンを期限切れとみなすまでの猶予です。
+// 有効期限の直前に取り出したトークンがリクエスト中に失効しないようにします。
+const tokenExpiryMargin = 30 * time.Second
+
+// StoredToken は [TokenStore] に保存されるトークンです。
+type StoredToken struct {
+	Scope     string    `json:"scope"`      // トークンスコープ
+	TokenType string    `json:"token_type"` // トークンタイプ
+	Token     string    `json:"token"`      // アクセストークン
+	ExpiresAt time.Time `json:"expires_at"` // 有効期限（不明な場合はゼロ値）
+}
+
+// Valid はトークンが now の時点で有効かどうかを返します。期限の直前は無効とみなします。
+// [Client.TokenValid] と同様に、有効期限が不明な場合は有効とみなします。
+func (t StoredToken) Valid(now time.Time) bool {
+	if t.Token == "" {
+		return false
+	}
+	return t.ExpiresAt.IsZero() || now.Add(tokenExpiryMargin).Before(t.ExpiresAt)
+}
+
+// storedToken は TokenResponse を取得時刻をもとに StoredToken に変換します。
+// ExpiresIn が含まれない場合、有効期限は不明としてゼロ値になります。
+func storedToken(res TokenResponse, now time.Time) StoredToken {
+	t := StoredToken{
+		Scope:     res.Scope,
+		TokenType: res.TokenType,
+		Token:     res.Token,
+	}
+	if res.ExpiresIn > 0 {
+		t.ExpiresAt = now.Add(time.Duration(res.ExpiresIn) * time.Second)
+	}
+	return t
+}
+
+// tokenResponse は StoredToken を残りの有効秒数を持つ TokenResponse に変換します。
+// 有効期限が不明な場合、ExpiresIn は 0 になります。
+func (t StoredToken) tokenResponse(now time.Time) TokenResponse {
+	res := TokenResponse{
+		Scope:     t.Scope,
+		TokenType: t.TokenType,
+		Token:     t.Token,
+	}
+	if !t.ExpiresAt.IsZero() {
+		res.ExpiresIn = int64(t.ExpiresAt.Sub(now) / time.Second)
+	}
+	return res
+}
+
+// TokenStore は複数のクライアントやプロセスでトークンを共有するための保存先です。
+type TokenStore interface {
+	// Load は保存されたトークンを返します。保存されていない場合は false を返します。
+	Load(ctx context.Context) (StoredToken, bool, error)
+	// Save はトークンを保存します。
+	Save(ctx context.Context, token StoredToken) error
+	// Lock はトークンの更新を1つの呼び出し元に限定するためのロックを取得し、解放する関数を返します。
+	// ロックを取得できるまでブロックし、ctx が終了した場合はそのエラーを返します。
+	Lock(ctx context.Context) (unlock func() error, err error)
+}
+
+// MemoryTokenStore はメモリ上にトークンを保存する [TokenStore] です。
+// 同一プロセス内の複数のクライアントでトークンを共有する場合に使います。
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *StoredToken
+	sem   chan struct{}
+}
+
+// NewMemoryTokenStore は空の MemoryTokenStore を生成します。
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{sem: make(chan struct{}, 1)}
+}
+
+// Load は保存されたトークンを返します。
+func (s *MemoryTokenStore) Load(ctx context.Context) (StoredToken, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return StoredToken{}, false, nil
+	}
+	return *s.token, true, nil
+}
+
+// Save はトークンを保存します。
+func (s *MemoryTokenStore) Save(ctx context.Context, token StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = &token
+	return nil
+}
+
+// Lock はトークン更新のためのロックを取得します。
+func (s *MemoryTokenStore) Lock(ctx context.Context) (func() error, error) {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() error {
+		once.Do(func() { <-s.sem })
+		return nil
+	}, nil
+}
+
+// FileTokenStore はファイルにトークンを保存する [TokenStore] です。
+// 同一ホスト上の複数のプロセスでトークンを共有する場合に使います。
+// ロックには保存先と同じディレクトリに作成する ".lock" ファイルを使います。
+type FileTokenStore struct {
+	path         string
+	pollInterval time.Duration
+	staleAfter   time.Duration
+}
+
+// NewFileTokenStore は path にトークンを保存する FileTokenStore を生成します。
+//
+// 引数:
+//   - path: トークンを保存するファイルのパス
+//
+// 戻り値:
+//   - *FileTokenStore: 生成された FileTokenStore
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{
+		path:         path,
+		pollInterval: 50 * time.Millisecond,
+		staleAfter:   time.Minute,
+	}
+}
+
+// SetStaleAfter はロックファイルを放棄されたものとみなすまでの時間を設定します。
+// ロックを保持したままプロセスが終了した場合でも、この時間が経過すれば他のプロセスがロックを取得できます。
+func (s *FileTokenStore) SetStaleAfter(d time.Duration) {
+	s.staleAfter = d
+}
+
+// Load は保存されたトークンを返します。ファイルが存在しない場合は false を返します。
+func (s *FileTokenStore) Load(ctx context.Context) (token StoredToken, ok bool, err error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = errors.Join(NewError(500, "token store read error"), err)
+		return
+	}
+	if err = json.Unmarshal(b, &token); err != nil {
+		err = errors.Join(NewError(500, "json decoding error"), err)
+		return
+	}
+	ok = true
+	return
+}
+
+// Save はトークンを保存します。一時ファイルに書き込んでから置き換えるため、読み込み中のプロセスが途中の内容を読むことはありません。
+func (s *FileTokenStore) Save(ctx context.Context, token StoredToken) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return errors.Join(NewError(500, "json encoding error"), err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.Join(NewError(500, "token store write error"), err)
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(b); err == nil {
+		err = f.Chmod(0o600)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path)
+	}
+	if err != nil {
+		return errors.Join(NewError(500, "token store write error"), err)
+	}
+	return nil
+}
+
+// Lock はロックファイルを作成してトークン更新のためのロックを取得します。
+// ロックファイルには取得した呼び出し元を識別する値を書き込み、解放時は自身のロックである場合にのみ削除します。
+// 放棄されたロックファイルの削除は ".lock.break" ファイルによって1つの呼び出し元に限定するため、
+// 複数の呼び出し元が同時に放棄されたロックを検出しても、ロックを取得できるのは1つだけです。
+func (s *FileTokenStore) Lock(ctx context.Context) (func() error, error) {
+	lockPath := s.path + ".lock"
+	owner := rand.Text()
+	for {
+		created, err := createExclusive(lockPath, owner)
+		if err != nil {
+			return nil, errors.Join(NewError(500, "token store lock error"), err)
+		}
+		if created {
+			var once sync.Once
+			return func() (err error) {
+				once.Do(func() { err = s.release(lockPath, owner) })
+				return
+			}, nil
+		}
+
+		// 放棄されたロックファイルは削除して取り直す
+		if removed, err := s.breakStale(lockPath); err != nil {
+			return nil, errors.Join(NewError(500, "token store lock error"), err)
+		} else if removed {
+			continue
+		}
+
+		select {
+		case <-time.After(s.pollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// createExclusive はファイルが存在しない場合にのみ作成し、content を書き込みます。
+// すでに存在する場合は false を返します。
+func createExclusive(path string, content string) (bool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = f.WriteString(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return false, err
+	}
+	return true, nil
+}
+
+// stale はファイルが staleAfter より前に作成された、放棄されたものかどうかを返します。
+func (s *FileTokenStore) stale(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && time.Since(info.ModTime()) > s.staleAfter
+}
+
+// withBreakLock は ".lock.break" ファイルによる排他のもとで fn を実行します。
+// ロックファイルの削除はすべてこの排他のもとで行うため、確認から削除までの間に他の呼び出し元のロックを削除することはありません。
+// wait が false の場合、排他を取得できなければ fn を実行せずに false を返します。
+func (s *FileTokenStore) withBreakLock(lockPath string, wait bool, fn func() error) (bool, error) {
+	breakPath := lockPath + ".break"
+	for {
+		created, err := createExclusive(breakPath, "")
+		if err != nil {
+			return false, err
+		}
+		if created {
+			defer os.Remove(breakPath)
+			return true, fn()
+		}
+		// 削除の途中でプロセスが終了した場合に備え、放棄された排他ファイルは削除する
+		if s.stale(breakPath) {
+			_ = os.Remove(breakPath)
+			continue
+		}
+		if !wait {
+			return false, nil
+		}
+		time.Sleep(s.pollInterval)
+	}
+}
+
+// breakStale はロックファイルが放棄されたものであれば削除し、削除した場合に true を返します。
+func (s *FileTokenStore) breakStale(lockPath string) (removed bool, err error) {
+	if !s.stale(lockPath) {
+		return false, nil
+	}
+	_, err = s.withBreakLock(lockPath, false, func() error {
+		// 排他を取得するまでの間に、他の呼び出し元が削除して取り直している場合がある
+		if !s.stale(lockPath) {
+			return nil
+		}
+		if err := os.Remove(lockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		removed = true
+		return nil
+	})
+	return
+}
+
+// release はロックファイルが owner のものである場合にのみ削除します。
+// 放棄されたものとして他の呼び出し元に削除された後であれば、何もしません。
+func (s *FileTokenStore) release(lockPath string, owner string) error {
+	_, err := s.withBreakLock(lockPath, true, func() error {
+		b, err := os.ReadFile(lockPath)
+		if errors.Is(err, os.ErrNotExist) || err == nil && string(b) != owner {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return os.Remove(lockPath)
+	})
+	return err
+}
+
+// SetTokenStore はトークンの保存先を設定します。
+// 設定すると [Client.GetToken] は保存先の有効なトークンを優先して返し、
+// 期限切れの場合はロックを取得した1つの呼び出し元だけがトークン取得APIを呼び出して保存先を更新します。
+// nil を指定すると無効になります。
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.tokenStore = store
+}
+
+// getStoredToken は保存先のトークンを返し、期限切れであればロックを取得して更新します。
+func (c *Client) getStoredToken(ctx context.Context) (res TokenResponse, err error) {
+	if t, ok, err := c.tokenStore.Load(ctx); err != nil {
+		return res, err
+	} else if now := time.Now(); ok && t.Valid(now) {
+		return t.tokenResponse(now), nil
+	}
+
+	unlock, err := c.tokenStore.Lock(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if unlockErr := unlock(); err == nil && unlockErr != nil {
+			err = errors.Join(NewError(500, "token store unlock error"), unlockErr)
+		}
+	}()
+
+	// ロックを待つ間に他の呼び出し元が更新している場合はそれを使う
+	if t, ok, err := c.tokenStore.Load(ctx); err != nil {
+		return res, err
+	} else if now := time.Now(); ok && t.Valid(now) {
+		return t.tokenResponse(now), nil
+	}
+
+	fetchedAt := time.Now()
+	if res, err = c.fetchToken(ctx); err != nil {
+		return
+	}
+	err = c.tokenStore.Save(ctx, storedToken(res, fetchedAt))
+	return
+}
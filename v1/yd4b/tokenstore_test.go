@@ -0,0 +1,291 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenStores はテスト対象の TokenStore をそれぞれ生成します。
+func newTokenStores(t *testing.T) map[string]yd4b.TokenStore {
+	return map[string]yd4b.TokenStore{
+		"memory": yd4b.NewMemoryTokenStore(),
+		"file":   yd4b.NewFileTokenStore(filepath.Join(t.TempDir(), "token.json")),
+	}
+}
+
+func TestStoredToken_Valid(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		token yd4b.StoredToken
+		want  bool
+	}{
+		{name: "valid", token: yd4b.StoredToken{Token: "t", ExpiresAt: now.Add(time.Hour)}, want: true},
+		{name: "expired", token: yd4b.StoredToken{Token: "t", ExpiresAt: now.Add(-time.Second)}, want: false},
+		{name: "about to expire", token: yd4b.StoredToken{Token: "t", ExpiresAt: now.Add(10 * time.Second)}, want: false},
+		{name: "empty token", token: yd4b.StoredToken{ExpiresAt: now.Add(time.Hour)}, want: false},
+		{name: "unknown expiry", token: yd4b.StoredToken{Token: "t"}, want: true},
+		{name: "empty token with unknown expiry", token: yd4b.StoredToken{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.token.Valid(now))
+		})
+	}
+}
+
+func TestTokenStore_LoadSave(t *testing.T) {
+	for name, store := range newTokenStores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			_, ok, err := store.Load(ctx)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			want := yd4b.StoredToken{Scope: "J1", TokenType: "Bearer", Token: "abc", ExpiresAt: time.Now().Add(time.Hour).Round(0)}
+			require.NoError(t, store.Save(ctx, want))
+			got, ok, err := store.Load(ctx)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.True(t, want.ExpiresAt.Equal(got.ExpiresAt))
+			got.ExpiresAt = want.ExpiresAt
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestTokenStore_Lock(t *testing.T) {
+	for name, store := range newTokenStores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			unlock, err := store.Lock(context.Background())
+			require.NoError(t, err)
+
+			// ロック中は他の呼び出し元がロックを取得できない
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			_, err = store.Lock(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+			// 解放後は取得できる
+			assert.NoError(t, unlock())
+			unlock, err = store.Lock(context.Background())
+			assert.NoError(t, err)
+			assert.NoError(t, unlock())
+		})
+	}
+}
+
+func TestFileTokenStore_StaleLock(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, os.WriteFile(path+".lock", nil, 0o600))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path+".lock", old, old))
+
+	store := yd4b.NewFileTokenStore(path)
+	store.SetStaleAfter(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	unlock, err := store.Lock(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, unlock())
+}
+
+func TestFileTokenStore_StaleLockContention(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, os.WriteFile(path+".lock", []byte("dead"), 0o600))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path+".lock", old, old))
+
+	// 放棄されたロックを同時に検出した呼び出し元のうち、同時にロックを保持するのは1つだけ
+	var holders, maxHolders, acquired int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store := yd4b.NewFileTokenStore(path)
+			store.SetStaleAfter(time.Minute)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			unlock, err := store.Lock(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+			n := atomic.AddInt32(&holders, 1)
+			for {
+				m := atomic.LoadInt32(&maxHolders)
+				if n <= m || atomic.CompareAndSwapInt32(&maxHolders, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&holders, -1)
+			atomic.AddInt32(&acquired, 1)
+			assert.NoError(t, unlock())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxHolders))
+	assert.Equal(t, int32(20), atomic.LoadInt32(&acquired))
+	assert.NoFileExists(t, path+".lock")
+}
+
+func TestFileTokenStore_UnlockAfterTakeover(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	slow := yd4b.NewFileTokenStore(path)
+	unlockSlow, err := slow.Lock(context.Background())
+	require.NoError(t, err)
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path+".lock", old, old))
+
+	// 放棄されたとみなされたロックは他の呼び出し元に引き継がれる
+	unlock, err := yd4b.NewFileTokenStore(path).Lock(context.Background())
+	require.NoError(t, err)
+
+	// 元の保持者が解放しても、引き継いだ呼び出し元のロックは削除されない
+	assert.NoError(t, unlockSlow())
+	assert.FileExists(t, path+".lock")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = yd4b.NewFileTokenStore(path).Lock(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.NoError(t, unlock())
+	assert.NoFileExists(t, path+".lock")
+}
+
+func TestClient_GetToken_TokenStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    *yd4b.StoredToken
+		wantToken string
+		wantCalls int32
+	}{
+		{name: "empty store", wantToken: "fresh", wantCalls: 1},
+		{name: "valid token", stored: &yd4b.StoredToken{Token: "cached", ExpiresAt: time.Now().Add(time.Hour)}, wantToken: "cached", wantCalls: 0},
+		{name: "expired token", stored: &yd4b.StoredToken{Token: "cached", ExpiresAt: time.Now().Add(-time.Hour)}, wantToken: "fresh", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			store := yd4b.NewMemoryTokenStore()
+			if tt.stored != nil {
+				require.NoError(t, store.Save(ctx, *tt.stored))
+			}
+
+			var calls int32
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetTokenStore(store)
+			client.SetDoFunc(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&calls, 1)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"scope":"J1","token_type":"Bearer","expires_in":600,"token":"fresh"}`)),
+				}, nil
+			})
+
+			res, err := client.GetTokenContext(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantToken, res.Token)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+
+			saved, ok, err := store.Load(ctx)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantToken, saved.Token)
+		})
+	}
+}
+
+func TestClient_GetToken_TokenStoreWithoutExpiry(t *testing.T) {
+	for name, store := range newTokenStores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// expires_in を含まないトークンは有効期限不明として保存され、再取得されない
+			var calls int32
+			doFunc := func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&calls, 1)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"scope":"J1","token_type":"Bearer","token":"no-expiry"}`)),
+				}, nil
+			}
+
+			for range 3 {
+				client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+				client.SetTokenStore(store)
+				client.SetDoFunc(doFunc)
+				res, err := client.GetToken()
+				require.NoError(t, err)
+				assert.Equal(t, "no-expiry", res.Token)
+				assert.Zero(t, res.ExpiresIn)
+			}
+			assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+			saved, ok, err := store.Load(context.Background())
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.True(t, saved.ExpiresAt.IsZero())
+		})
+	}
+}
+
+func TestClient_GetToken_TokenStoreShared(t *testing.T) {
+	t.Parallel()
+
+	// 同じファイルを共有する複数のクライアントのうち、1つだけがトークンを取得する
+	path := filepath.Join(t.TempDir(), "token.json")
+	var calls int32
+	doFunc := func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"expires_in":600,"token":"shared"}`)),
+		}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetTokenStore(yd4b.NewFileTokenStore(path))
+			client.SetDoFunc(doFunc)
+			res, err := client.GetToken()
+			assert.NoError(t, err)
+			assert.Equal(t, "shared", res.Token)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,318 @@
+// Package vcr は [yd4b.Client.SetDoFunc] に渡す記録・再生用のトランスポートを提供するパッケージです。
+//
+// 記録モードでは実際のリクエストとレスポンスの組をカセットファイルに書き出し、
+// 再生モードではカセットファイルから一致するレスポンスを返します。
+// 一度実際の API の挙動を記録しておけば、以降のテストをオフラインで決定的に実行できます。
+//
+// [yd4b.Client.SetDoFunc]: https://pkg.go.dev/github.com/aethiopicuschan/yd4b-go/v1/yd4b#Client.SetDoFunc
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sync"
+)
+
+// Redacted は秘匿情報を置き換える文字列です。
+const Redacted = "REDACTED"
+
+// ErrInteractionNotFound は再生モードで一致する記録が見つからない場合のエラーです。
+var ErrInteractionNotFound = errors.New("vcr: no matching interaction in cassette")
+
+// Mode は Recorder の動作モードです。
+type Mode int
+
+const (
+	ModeReplay Mode = iota // カセットファイルから再生する
+	ModeRecord             // 実際にリクエストを送信してカセットファイルに記録する
+)
+
+// Request は記録されたリクエストです。
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response は記録されたレスポンスです。
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction はリクエストとレスポンスの組です。
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette はカセットファイルの内容です。
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder はリクエストとレスポンスを記録・再生するトランスポートです。
+type Recorder struct {
+	mu            sync.Mutex
+	path          string
+	mode          Mode
+	next          func(req *http.Request) (*http.Response, error)
+	cassette      Cassette
+	used          []bool
+	redactHeaders []string
+	redactFields  []string
+}
+
+// New は新しい Recorder を生成します。
+// 再生モードでは path のカセットファイルを読み込み、記録モードでは path に新しいカセットファイルを書き出します。
+//
+// 引数:
+//   - path: カセットファイルのパス
+//   - mode: 動作モード
+//   - next: 記録モードで実際にリクエストを送信する関数（nil の場合は http.DefaultClient.Do、再生モードでは使われません）
+//
+// 戻り値:
+//   - *Recorder: 生成された Recorder
+//   - error: カセットファイルの読み込みに失敗した場合のエラー
+func New(path string, mode Mode, next func(req *http.Request) (*http.Response, error)) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultClient.Do
+	}
+	r := &Recorder{
+		path:          path,
+		mode:          mode,
+		next:          next,
+		redactHeaders: []string{"Authorization"},
+		redactFields:  []string{"secret_key", "token"},
+	}
+	if mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("vcr: read cassette: %w", err)
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("vcr: decode cassette: %w", err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// SetRedactedHeaders は記録時に値を置き換えるヘッダを設定します。既定値は Authorization です。
+func (r *Recorder) SetRedactedHeaders(headers ...string) {
+	r.redactHeaders = headers
+}
+
+// SetRedactedFields は記録時に値を置き換える JSON ボディのフィールドを設定します。
+// リクエストとレスポンスの両方に適用され、既定値は secret_key と token です。
+func (r *Recorder) SetRedactedFields(fields ...string) {
+	r.redactFields = fields
+}
+
+// Do はモードに応じてリクエストを記録または再生します。[yd4b.Client.SetDoFunc] に渡して使います。
+//
+// [yd4b.Client.SetDoFunc]: https://pkg.go.dev/github.com/aethiopicuschan/yd4b-go/v1/yd4b#Client.SetDoFunc
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+// recordRequest はリクエストを読み出して秘匿情報を置き換えた Request を返します。リクエストのボディは読み直せるように戻します。
+func (r *Recorder) recordRequest(req *http.Request) (Request, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return Request{}, fmt.Errorf("vcr: read request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: r.redactHeader(req.Header),
+		Body:   r.redactBody(body),
+	}, nil
+}
+
+// record は実際にリクエストを送信し、結果をカセットファイルに追記します。
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.next(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("vcr: read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.redactHeader(resp.Header),
+			Body:       r.redactBody(body),
+		},
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save はカセットファイルを書き出します。呼び出し元が mu を保持している必要があります。
+func (r *Recorder) save() error {
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("vcr: encode cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("vcr: write cassette: %w", err)
+	}
+	return nil
+}
+
+// replay は一致する記録のレスポンスを返します。
+// 同じリクエストが複数記録されている場合は記録された順に返し、使い切った後は最後のものを返し続けます。
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := -1
+	for i, it := range r.cassette.Interactions {
+		if !matches(it.Request, recorded) {
+			continue
+		}
+		found = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, recorded.Method, recorded.URL)
+	}
+	r.used[found] = true
+
+	res := r.cassette.Interactions[found].Response
+	header := res.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+		StatusCode:    res.StatusCode,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(res.Body)),
+		ContentLength: int64(len(res.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches はメソッド、パス、クエリ、ボディが一致するかどうかを返します。
+// クエリはパラメータの順序を、JSON ボディはキーの順序や空白を区別しません。
+func matches(a, b Request) bool {
+	if a.Method != b.Method {
+		return false
+	}
+	ua, errA := url.Parse(a.URL)
+	ub, errB := url.Parse(b.URL)
+	if errA != nil || errB != nil {
+		return a.URL == b.URL && equalBody(a.Body, b.Body)
+	}
+	if ua.Path != ub.Path {
+		return false
+	}
+	if !maps.EqualFunc(ua.Query(), ub.Query(), slices.Equal) {
+		return false
+	}
+	return equalBody(a.Body, b.Body)
+}
+
+// equalBody はボディが一致するかどうかを返します。両方が JSON の場合は値として比較します。
+func equalBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
+
+// redactHeader はヘッダを複製し、秘匿するヘッダの値を置き換えます。
+func (r *Recorder) redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	h = h.Clone()
+	for _, name := range r.redactHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// redactBody は JSON ボディ中の秘匿するフィールドの値を置き換えます。JSON でない場合はそのまま返します。
+func (r *Recorder) redactBody(body []byte) string {
+	if len(body) == 0 || len(r.redactFields) == 0 {
+		return string(body)
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	if !redactValue(v, r.redactFields) {
+		return string(body)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+// redactValue は JSON の値を再帰的にたどってフィールドを置き換え、置き換えたかどうかを返します。
+func redactValue(v any, fields []string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if slices.Contains(fields, k) {
+				v[k] = Redacted
+				changed = true
+				continue
+			}
+			changed = redactValue(child, fields) || changed
+		}
+	case []any:
+		for _, child := range v {
+			changed = redactValue(child, fields) || changed
+		}
+	}
+	return changed
+}
@@ -0,0 +1,145 @@
+package vcr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b/vcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI は実際の API の代わりにトークンと検索結果を返す doFunc です。
+func fakeAPI(calls *int) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		*calls++
+		body := `{"count":1,"addresses":[{"zip_code":"1000001","pref_name":"東京都"}]}`
+		switch req.URL.Path {
+		case "/api/v1/j/token":
+			body = `{"scope":"J1","token_type":"Bearer","expires_in":600,"token":"live-token"}`
+		case "/api/v1/searchcode/0000000":
+			return &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: io.NopCloser(bytes.NewBufferString(`{"message":"not found"}`))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	}
+}
+
+// record は fakeAPI に対する一連の呼び出しを記録したカセットファイルのパスを返します。
+func record(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	var calls int
+	rec, err := vcr.New(path, vcr.ModeRecord, fakeAPI(&calls))
+	require.NoError(t, err)
+
+	client := yd4b.NewClient("https://api.example.com", "id", "super-secret", "1.2.3.4")
+	client.SetDoFunc(rec.Do)
+	res, err := client.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "live-token", res.Token, "記録中は実際のレスポンスを返す")
+	client.SetToken(res.Token)
+
+	_, err = client.Searchcode("1000001", yd4b.WithSCPage(1), yd4b.WithSCLimit(10))
+	require.NoError(t, err)
+	_, err = client.AddressZip(yd4b.WithPrefCode("13"), yd4b.WithTownName("千代田"))
+	require.NoError(t, err)
+	_, err = client.Searchcode("0000000")
+	require.Error(t, err)
+	assert.Equal(t, 4, calls)
+	return path
+}
+
+func TestRecorder_Record(t *testing.T) {
+	t.Parallel()
+
+	path := record(t)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "super-secret")
+	assert.NotContains(t, string(b), "live-token")
+
+	var cassette vcr.Cassette
+	require.NoError(t, json.Unmarshal(b, &cassette))
+	require.Len(t, cassette.Interactions, 4)
+	assert.JSONEq(t, `{"grant_type":"client_credentials","client_id":"id","secret_key":"REDACTED"}`, cassette.Interactions[0].Request.Body)
+	assert.Contains(t, cassette.Interactions[0].Response.Body, `"token":"REDACTED"`)
+	assert.Equal(t, vcr.Redacted, cassette.Interactions[1].Request.Header.Get("Authorization"))
+	assert.Equal(t, http.StatusNotFound, cassette.Interactions[3].Response.StatusCode)
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	t.Parallel()
+
+	path := record(t)
+	rec, err := vcr.New(path, vcr.ModeReplay, nil)
+	require.NoError(t, err)
+
+	client := yd4b.NewClient("https://api.example.com", "id", "other-secret", "1.2.3.4")
+	client.SetDoFunc(rec.Do)
+	client.SetToken("other-token")
+
+	// 秘匿した値やクエリの順序が異なっても一致する
+	sc, err := client.Searchcode("1000001", yd4b.WithSCLimit(10), yd4b.WithSCPage(1))
+	require.NoError(t, err)
+	assert.Equal(t, "東京都", sc.Addresses[0].PrefName)
+
+	// 同じリクエストは何度でも再生できる
+	for range 2 {
+		az, err := client.AddressZip(yd4b.WithTownName("千代田"), yd4b.WithPrefCode("13"))
+		require.NoError(t, err)
+		assert.Equal(t, "1000001", az.Addresses[0].ZipCode)
+	}
+
+	_, err = client.GetToken()
+	assert.NoError(t, err)
+
+	_, err = client.Searchcode("0000000")
+	var yerr *yd4b.Error
+	require.ErrorAs(t, err, &yerr)
+	assert.Equal(t, http.StatusNotFound, yerr.StatusCode)
+}
+
+func TestRecorder_ReplayNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(c *yd4b.Client) error
+	}{
+		{name: "different path", call: func(c *yd4b.Client) error { _, err := c.Searchcode("2000001"); return err }},
+		{name: "different query", call: func(c *yd4b.Client) error {
+			_, err := c.Searchcode("1000001", yd4b.WithSCPage(2), yd4b.WithSCLimit(10))
+			return err
+		}},
+		{name: "different body", call: func(c *yd4b.Client) error {
+			_, err := c.AddressZipContext(context.Background(), yd4b.WithPrefCode("27"))
+			return err
+		}},
+	}
+
+	path := record(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec, err := vcr.New(path, vcr.ModeReplay, nil)
+			require.NoError(t, err)
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(rec.Do)
+			assert.ErrorIs(t, tt.call(client), vcr.ErrInteractionNotFound)
+		})
+	}
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	t.Parallel()
+
+	_, err := vcr.New(filepath.Join(t.TempDir(), "missing.json"), vcr.ModeReplay, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,174 @@
+package yd4b
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b/normalize"
+)
+
+// Verdict は住所検証の判定結果を表す型です。
+type Verdict string
+
+const (
+	VerdictExact      Verdict = "exact"      // すべての項目が完全に一致
+	VerdictNormalized Verdict = "normalized" // 表記揺れを正規化すると一致
+	VerdictPartial    Verdict = "partial"    // 一部の項目が不一致
+	VerdictMismatch   Verdict = "mismatch"   // すべての項目が不一致、または郵便番号が存在しない
+)
+
+// AddressComponent は住所の構成要素を表す型です。
+type AddressComponent string
+
+const (
+	ComponentPref AddressComponent = "pref" // 都道府県
+	ComponentCity AddressComponent = "city" // 市区町村
+	ComponentTown AddressComponent = "town" // 町域
+)
+
+// Verification は住所検証の結果を表す構造体です。
+type Verification struct {
+	Verdict     Verdict                 `json:"verdict"`     // 判定結果
+	Mismatched  []AddressComponent      `json:"mismatched"`  // 不一致だった項目
+	Candidate   *SearchcodeAddressItem  `json:"candidate"`   // 最も一致した候補（郵便番号が存在しない場合は nil）
+	Suggestions []SearchcodeAddressItem `json:"suggestions"` // 修正候補（一致度の高い順）
+}
+
+// stripTownNote は町域名の括弧書きを取り除きます。
+func stripTownNote(s string) string {
+	for _, open := range []string{"（", "("} {
+		if i := strings.Index(s, open); i >= 0 {
+			s = s[:i]
+		}
+	}
+	return s
+}
+
+// componentMatch は項目の一致の度合いを表します。
+type componentMatch int
+
+const (
+	componentDiffer componentMatch = iota
+	componentNormalized
+	componentExact
+)
+
+// compareComponent は入力値と候補の値を比較します。
+func compareComponent(input, candidate string) componentMatch {
+	switch {
+	case input == "":
+		return componentDiffer
+	case input == candidate:
+		return componentExact
+	case normalize.Equal(input, candidate):
+		return componentNormalized
+	default:
+		return componentDiffer
+	}
+}
+
+// compareTown は入力された町域名と候補の町域名を比較します。
+// 括弧書きや丁目・番地などの付記は無視します。
+func compareTown(input, candidate string) componentMatch {
+	if m := compareComponent(input, candidate); m != componentDiffer {
+		return m
+	}
+	town, _ := splitTown(normalize.Key(input))
+	if input != "" && normalize.Key(stripTownNote(candidate)) == town {
+		return componentNormalized
+	}
+	return componentDiffer
+}
+
+// candidateResult は候補ごとの比較結果です。
+type candidateResult struct {
+	item       SearchcodeAddressItem
+	mismatched []AddressComponent
+	normalized bool
+}
+
+// Verify は入力された都道府県・市区町村・町域が郵便番号と一致するかを検証します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - zip: 郵便番号
+//   - prefName: 入力された都道府県名
+//   - cityName: 入力された市区町村名
+//   - townName: 入力された町域名
+//
+// 戻り値:
+//   - Verification: 判定結果と修正候補
+//   - error: 通信エラーなど（郵便番号が存在しない場合はエラーではなく VerdictMismatch を返します）
+func (c *Client) Verify(ctx context.Context, zip string, prefName string, cityName string, townName string) (v Verification, err error) {
+	v.Mismatched = []AddressComponent{}
+	v.Suggestions = []SearchcodeAddressItem{}
+
+	res, err := c.SearchcodeContext(ctx, zip)
+	if err != nil {
+		var ye *Error
+		if errors.As(err, &ye) && ye.StatusCode == http.StatusNotFound {
+			v.Verdict = VerdictMismatch
+			v.Mismatched = []AddressComponent{ComponentPref, ComponentCity, ComponentTown}
+			err = nil
+		}
+		return
+	}
+	if len(res.Addresses) == 0 {
+		v.Verdict = VerdictMismatch
+		v.Mismatched = []AddressComponent{ComponentPref, ComponentCity, ComponentTown}
+		return
+	}
+
+	results := make([]candidateResult, 0, len(res.Addresses))
+	for _, item := range res.Addresses {
+		r := candidateResult{item: item, mismatched: []AddressComponent{}}
+		checks := []struct {
+			component AddressComponent
+			match     componentMatch
+		}{
+			{ComponentPref, compareComponent(prefName, item.PrefName)},
+			{ComponentCity, compareComponent(cityName, item.CityName)},
+			{ComponentTown, compareTown(townName, item.TownName)},
+		}
+		for _, check := range checks {
+			switch check.match {
+			case componentDiffer:
+				r.mismatched = append(r.mismatched, check.component)
+			case componentNormalized:
+				r.normalized = true
+			}
+		}
+		results = append(results, r)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if len(results[i].mismatched) != len(results[j].mismatched) {
+			return len(results[i].mismatched) < len(results[j].mismatched)
+		}
+		return !results[i].normalized && results[j].normalized
+	})
+
+	best := results[0]
+	v.Candidate = &best.item
+	v.Mismatched = best.mismatched
+	switch {
+	case len(best.mismatched) == 0 && !best.normalized:
+		v.Verdict = VerdictExact
+	case len(best.mismatched) == 0:
+		v.Verdict = VerdictNormalized
+	case len(best.mismatched) < 3:
+		v.Verdict = VerdictPartial
+	default:
+		v.Verdict = VerdictMismatch
+	}
+
+	if v.Verdict != VerdictExact {
+		for _, r := range results {
+			v.Suggestions = append(v.Suggestions, r.item)
+		}
+	}
+	return
+}
@@ -0,0 +1,130 @@
+package yd4b_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Verify(t *testing.T) {
+	ok := func(body string) func(req *http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		}
+	}
+	chiyoda := `{"page":1,"limit":10,"count":1,"searchtype":"zipcode","addresses":[{"zip_code":"1000001","pref_code":"13","pref_name":"東京都","city_code":"13101","city_name":"千代田区","town_name":"千代田"}]}`
+	otemachi := `{"page":1,"limit":10,"count":1,"searchtype":"zipcode","addresses":[{"zip_code":"1000004","pref_code":"13","pref_name":"東京都","city_code":"13101","city_name":"千代田区","town_name":"大手町（次のビルを除く）"}]}`
+
+	tests := []struct {
+		name            string
+		doFunc          func(req *http.Request) (*http.Response, error)
+		pref            string
+		city            string
+		town            string
+		wantVerdict     yd4b.Verdict
+		wantMismatched  []yd4b.AddressComponent
+		wantSuggestions int
+		wantErrSubstr   string
+	}{
+		{
+			name:           "exact",
+			doFunc:         ok(chiyoda),
+			pref:           "東京都",
+			city:           "千代田区",
+			town:           "千代田",
+			wantVerdict:    yd4b.VerdictExact,
+			wantMismatched: []yd4b.AddressComponent{},
+		},
+		{
+			name:            "normalized with spaces and block number",
+			doFunc:          ok(otemachi),
+			pref:            "東京都",
+			city:            "千代田区 ",
+			town:            "大手町１丁目",
+			wantVerdict:     yd4b.VerdictNormalized,
+			wantMismatched:  []yd4b.AddressComponent{},
+			wantSuggestions: 1,
+		},
+		{
+			name:            "normalized variant",
+			doFunc:          ok(strings.ReplaceAll(chiyoda, "千代田\"}", "霞が関\"}")),
+			pref:            "東京都",
+			city:            "千代田区",
+			town:            "霞ヶ関",
+			wantVerdict:     yd4b.VerdictNormalized,
+			wantMismatched:  []yd4b.AddressComponent{},
+			wantSuggestions: 1,
+		},
+		{
+			name:            "town with kanji numeral and block number",
+			doFunc:          ok(strings.ReplaceAll(chiyoda, "千代田\"}", "一番町\"}")),
+			pref:            "東京都",
+			city:            "千代田区",
+			town:            "一番町１０",
+			wantVerdict:     yd4b.VerdictNormalized,
+			wantMismatched:  []yd4b.AddressComponent{},
+			wantSuggestions: 1,
+		},
+		{
+			name:            "partial",
+			doFunc:          ok(chiyoda),
+			pref:            "東京都",
+			city:            "中央区",
+			town:            "千代田",
+			wantVerdict:     yd4b.VerdictPartial,
+			wantMismatched:  []yd4b.AddressComponent{yd4b.ComponentCity},
+			wantSuggestions: 1,
+		},
+		{
+			name:            "mismatch",
+			doFunc:          ok(chiyoda),
+			pref:            "大阪府",
+			city:            "大阪市北区",
+			town:            "梅田",
+			wantVerdict:     yd4b.VerdictMismatch,
+			wantMismatched:  []yd4b.AddressComponent{yd4b.ComponentPref, yd4b.ComponentCity, yd4b.ComponentTown},
+			wantSuggestions: 1,
+		},
+		{
+			name: "unknown zip",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+			},
+			wantVerdict:    yd4b.VerdictMismatch,
+			wantMismatched: []yd4b.AddressComponent{yd4b.ComponentPref, yd4b.ComponentCity, yd4b.ComponentTown},
+		},
+		{
+			name: "client do error",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("fail")
+			},
+			wantErrSubstr: "client do error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			client.SetDoFunc(tt.doFunc)
+
+			v, err := client.Verify(context.Background(), "1000001", tt.pref, tt.city, tt.town)
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVerdict, v.Verdict)
+			assert.Equal(t, tt.wantMismatched, v.Mismatched)
+			assert.Len(t, v.Suggestions, tt.wantSuggestions)
+		})
+	}
+}
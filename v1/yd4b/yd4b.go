@@ -1,20 +1,44 @@
 // 郵便番号・デジタルアドレス for Bizのクライアントライブラリ
 package yd4b
 
+//go:generate go run ./internal/apispec/gen -spec internal/apispec/openapi.json -checksum internal/apispec/openapi.json.sha256 -types apitypes.json -out apitypes_gen.go -package yd4b
+
 import (
+	"context"
 	"net/http"
+	"sync"
+	"time"
 )
 
 // クライアントの実体
 type Client struct {
-	version      string                                          // APIのバージョン
-	origin       string                                          // APIサーバのオリジン
-	clientID     string                                          // クライアントID
-	clientSecret string                                          // クライアントシークレット
-	token        string                                          // API利用トークン
-	myip         string                                          // クライアントのグローバルIPアドレス（x-forwarded-for ヘッダに設定）
-	ecuid        string                                          // プロバイダーのユーザーID
-	doFunc       func(req *http.Request) (*http.Response, error) // HTTPクライアントのDoメソッドをラップする関数
+	mu               sync.RWMutex                                     // token, tokenScope, tokenExpires, ipResolver, resolvedIP, ipResolverGen, rateLimit 関連のフィールドを保護する
+	version          string                                           // APIのバージョン
+	origin           string                                           // APIサーバのオリジン
+	clientID         string                                           // クライアントID
+	clientSecret     string                                           // クライアントシークレット
+	token            string                                           // API利用トークン
+	tokenScope       string                                           // API利用トークンのスコープ
+	tokenExpires     time.Time                                        // API利用トークンの有効期限（ゼロ値の場合は不明）
+	myip             string                                           // クライアントのグローバルIPアドレス（x-forwarded-for ヘッダに設定）
+	ecuid            string                                           // プロバイダーのユーザーID
+	doFunc           func(req *http.Request) (*http.Response, error)  // HTTPクライアントのDoメソッドをラップする関数
+	flight           *flightGroup                                     // 同一リクエストの集約（nil の場合は無効）
+	tokenStore       TokenStore                                       // トークンの保存先（nil の場合は無効）
+	secretProvider   SecretProvider                                   // クライアントシークレットの提供元（nil の場合は clientSecret を使う）
+	ipResolver       IPResolver                                       // 送信元IPアドレスの検出方法（nil の場合は無効）
+	resolvedIP       string                                           // ipResolver で検出したIPアドレスのキャッシュ
+	ipResolverGen    uint64                                           // SetIPResolver の呼び出し回数（古い検出結果を破棄するために使う）
+	decodeMode       DecodeMode                                       // レスポンスの未知のフィールドの扱い
+	unknownFieldHook UnknownFieldHook                                 // 未知のフィールドを通知する関数（nil の場合は通知しない）
+	rateLimit        RateLimitStatus                                  // 最後に受信したレート制限の状態
+	rateLimitSeen    bool                                             // rateLimit を受信したかどうか
+	rateLimitBudget  int                                              // 送信済みのリクエストを差し引いた残りのリクエスト数の見積もり
+	throttle         *ThrottlePolicy                                  // 送信間隔の調整（nil の場合は無効）
+	sleep            func(ctx context.Context, d time.Duration) error // 送信間隔の調整に使う待機関数
+	breaker          *CircuitBreaker                                  // サーキットブレーカー（nil の場合は無効）
+	hedge            *HedgePolicy                                     // ヘッジリクエストの設定（nil の場合は無効）
+	fallback         FallbackFunc                                     // API を利用できない場合の代わりのレスポンス（nil の場合は無効）
 }
 
 // [Client]のコンストラクタ
@@ -27,6 +51,7 @@ func NewClient(origin string, clientID string, clientSecret string, myip string)
 		myip:         myip,
 		ecuid:        "",
 		doFunc:       http.DefaultClient.Do,
+		sleep:        sleepContext,
 	}
 }
 
@@ -35,21 +60,83 @@ func (c *Client) Version() string {
 	return c.version
 }
 
+// クライアントIDを返す
+func (c *Client) ClientID() string {
+	return c.clientID
+}
+
 // Doメソッドを書き換える
 func (c *Client) SetDoFunc(do func(req *http.Request) (*http.Response, error)) {
 	c.doFunc = do
 }
 
 // API利用トークンを設定する
+// 有効期限とスコープは不明として扱う
 func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.token = token
+	c.tokenScope = ""
+	c.tokenExpires = time.Time{}
+}
+
+// トークン取得APIのレスポンスからAPI利用トークンを設定する
+// 呼び出した時刻を発行時刻とみなし、ExpiresIn から有効期限を記録する
+func (c *Client) SetTokenResponse(res TokenResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = res.Token
+	c.tokenScope = res.Scope
+	c.tokenExpires = time.Time{}
+	if res.ExpiresIn > 0 {
+		c.tokenExpires = time.Now().Add(time.Duration(res.ExpiresIn) * time.Second)
+	}
 }
 
 // API利用トークンが設定されているかどうかを確認する
 func (c *Client) HasToken() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.token != ""
 }
 
+// API利用トークンの有効期限を返す
+// SetToken で設定した場合など、有効期限が不明な場合は false を返す
+func (c *Client) TokenExpiresAt() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tokenExpires, !c.tokenExpires.IsZero()
+}
+
+// API利用トークンが now の時点で有効かどうかを確認する
+// トークンが設定されていない場合は無効、有効期限が不明な場合は有効とみなす
+func (c *Client) TokenValid(now time.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.token == "" {
+		return false
+	}
+	return c.tokenExpires.IsZero() || now.Before(c.tokenExpires)
+}
+
+// API利用トークンのスコープを返す
+// SetToken で設定した場合など、スコープが不明な場合は空文字列を返す
+func (c *Client) Scope() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tokenScope
+}
+
+// 同時に発生した同一リクエストを1回の上流リクエストにまとめるかどうかを設定する
+// searchcode はコードとオプション、addresszip はリクエストボディが一致する場合に同一とみなす
+func (c *Client) SetSingleflight(enabled bool) {
+	if enabled {
+		c.flight = newFlightGroup()
+	} else {
+		c.flight = nil
+	}
+}
+
 // ECUIDを設定する
 func (c *Client) SetECUID(ecuid string) {
 	c.ecuid = ecuid
@@ -57,10 +144,74 @@ func (c *Client) SetECUID(ecuid string) {
 
 // 設定されたDoメソッドを実行する
 func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ip, _, err := c.forwardedIP(req.Context())
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-forwarded-for", c.myip)
-	if c.HasToken() {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("x-forwarded-for", ip)
+	c.mu.RLock()
+	token := c.token
+	c.mu.RUnlock()
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.send(req)
+	cause := upstreamFailure(resp, err)
+	canceled := err != nil && req.Context().Err() != nil
+	if cause != nil && !canceled && c.fallback != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return c.useFallback(req, cause)
+	}
+	return resp, err
+}
+
+// リクエストを送信する
+// 同一リクエストの集約が有効な場合は、同時に発生した同一リクエストと結果を共有する
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.flight == nil {
+		return c.sendUpstream(req)
+	}
+	key, err := flightKey(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.flight.do(req.Context(), key, func(ctx context.Context) (*http.Response, error) {
+		return c.sendUpstream(req.WithContext(ctx))
+	})
+}
+
+// 上流にリクエストを1回送信する
+// レート制限による待機の後にサーキットブレーカーの判定を行い、結果は上流リクエストごとに1回だけ記録する
+func (c *Client) sendUpstream(req *http.Request) (*http.Response, error) {
+	if d := c.throttleDelay(time.Now()); d > 0 {
+		if err := c.sleep(req.Context(), d); err != nil {
+			return nil, err
+		}
+	}
+	var done func(breakerOutcome)
+	if c.breaker != nil {
+		var err error
+		if done, err = c.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.hedgedDo(req)
+	if err == nil {
+		c.observeRateLimit(resp.Header)
+	}
+	if done != nil {
+		switch {
+		case upstreamFailure(resp, err) == nil:
+			done(outcomeSuccess)
+		case err != nil && req.Context().Err() != nil:
+			done(outcomeIgnored)
+		default:
+			done(outcomeFailure)
+		}
 	}
-	return c.doFunc(req)
+	return resp, err
 }
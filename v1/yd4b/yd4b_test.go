@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
 	"github.com/stretchr/testify/assert"
@@ -97,3 +98,59 @@ func TestClientMethods(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_TokenMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(c *yd4b.Client)
+		wantExpires bool
+		wantScope   string
+		wantValid   map[time.Duration]bool // 現在時刻からの経過時間 → 有効かどうか
+	}{
+		{
+			name:      "no token",
+			setup:     func(c *yd4b.Client) {},
+			wantValid: map[time.Duration]bool{0: false},
+		},
+		{
+			name:      "SetToken",
+			setup:     func(c *yd4b.Client) { c.SetToken("t") },
+			wantValid: map[time.Duration]bool{0: true, 24 * time.Hour: true},
+		},
+		{
+			name:        "SetTokenResponse",
+			setup:       func(c *yd4b.Client) { c.SetTokenResponse(yd4b.TokenResponse{Scope: "J1", ExpiresIn: 600, Token: "t"}) },
+			wantExpires: true,
+			wantScope:   "J1",
+			wantValid:   map[time.Duration]bool{0: true, 9 * time.Minute: true, 11 * time.Minute: false},
+		},
+		{
+			name: "SetToken after SetTokenResponse",
+			setup: func(c *yd4b.Client) {
+				c.SetTokenResponse(yd4b.TokenResponse{Scope: "J1", ExpiresIn: 600, Token: "t"})
+				c.SetToken("u")
+			},
+			wantValid: map[time.Duration]bool{11 * time.Minute: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+			before := time.Now()
+			tt.setup(client)
+
+			expiresAt, ok := client.TokenExpiresAt()
+			assert.Equal(t, tt.wantExpires, ok)
+			if ok {
+				assert.WithinDuration(t, before.Add(600*time.Second), expiresAt, time.Second)
+			}
+			assert.Equal(t, tt.wantScope, client.Scope())
+			for d, want := range tt.wantValid {
+				assert.Equal(t, want, client.TokenValid(before.Add(d)), d)
+			}
+		})
+	}
+}
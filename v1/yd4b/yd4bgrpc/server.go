@@ -0,0 +1,242 @@
+// Package yd4bgrpc は [yd4b.Client] を gRPC サービスとして公開するためのパッケージです。
+//
+// サービス定義は yd4b.proto にあり、生成されたクライアント [AddressLookupClient] をそのまま利用できます。
+package yd4bgrpc
+
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.73.0 generate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultPageSize はストリームでページサイズが指定されていない場合の1ページあたりの件数です。
+const defaultPageSize = 100
+
+// Server は [yd4b.Client] をバックエンドとする AddressLookup サービスの実装です。
+type Server struct {
+	UnimplementedAddressLookupServer
+	client *yd4b.Client
+}
+
+// NewServer は新しい Server を生成します。
+//
+// 引数:
+//   - client: 上流のAPIを呼び出すクライアント（トークン設定済みであること）
+//
+// 戻り値:
+//   - *Server: 生成された Server
+func NewServer(client *yd4b.Client) *Server {
+	return &Server{client: client}
+}
+
+// Register は Server を gRPC サーバに登録します。
+func (s *Server) Register(gs grpc.ServiceRegistrar) {
+	RegisterAddressLookupServer(gs, s)
+}
+
+// Searchcode は郵便番号・事業所個別郵便番号・デジタルアドレスから住所を検索します。
+func (s *Server) Searchcode(ctx context.Context, req *SearchcodeRequest) (*SearchcodeResponse, error) {
+	res, err := s.searchcode(ctx, req, int(req.GetPage()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return fromSearchcodeResponse(res), nil
+}
+
+// SearchcodeStream は Searchcode の全ページを順に取得し、住所を1件ずつ送信します。
+func (s *Server) SearchcodeStream(req *SearchcodeRequest, stream grpc.ServerStreamingServer[SearchcodeAddressItem]) error {
+	ctx := stream.Context()
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+	for sent := 0; ; page++ {
+		res, err := s.searchcode(ctx, req, page, limit)
+		if err != nil {
+			return toStatus(err)
+		}
+		for _, item := range res.Addresses {
+			if err := stream.Send(fromSearchcodeAddressItem(item)); err != nil {
+				return err
+			}
+		}
+		sent += len(res.Addresses)
+		if len(res.Addresses) < limit || sent >= res.Count {
+			return nil
+		}
+	}
+}
+
+// AddressZip は住所情報から郵便番号を検索します。
+func (s *Server) AddressZip(ctx context.Context, req *AddressZipRequest) (*AddressZipResponse, error) {
+	res, err := s.addressZip(ctx, req, int(req.GetPage()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return fromAddressResponse(res), nil
+}
+
+// AddressZipStream は AddressZip の全ページを順に取得し、住所を1件ずつ送信します。
+func (s *Server) AddressZipStream(req *AddressZipRequest, stream grpc.ServerStreamingServer[AddressItem]) error {
+	ctx := stream.Context()
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+	for sent := 0; ; page++ {
+		res, err := s.addressZip(ctx, req, page, limit)
+		if err != nil {
+			return toStatus(err)
+		}
+		for _, item := range res.Addresses {
+			if err := stream.Send(fromAddressItem(item)); err != nil {
+				return err
+			}
+		}
+		sent += len(res.Addresses)
+		if len(res.Addresses) < limit || sent >= res.Count {
+			return nil
+		}
+	}
+}
+
+// pagination はストリームで使う開始ページとページサイズを返します。
+func pagination(page, limit int32) (int, int) {
+	p, l := int(page), int(limit)
+	if p <= 0 {
+		p = 1
+	}
+	if l <= 0 {
+		l = defaultPageSize
+	}
+	return p, l
+}
+
+// searchcode はリクエストの条件で Searchcode を呼び出します。
+// ゼロ値のオプションは上流のリクエストで省略されます。
+func (s *Server) searchcode(ctx context.Context, req *SearchcodeRequest, page, limit int) (yd4b.SearchcodeResponse, error) {
+	return s.client.SearchcodeContext(ctx, req.GetCode(),
+		yd4b.WithSCPage(page),
+		yd4b.WithSCLimit(limit),
+		yd4b.WithChoikitype(int(req.GetChoikitype())),
+		yd4b.WithSearchtype(int(req.GetSearchtype())),
+	)
+}
+
+// addressZip はリクエストの条件で AddressZip を呼び出します。
+// ゼロ値のオプションは上流のリクエストで省略されます。
+func (s *Server) addressZip(ctx context.Context, req *AddressZipRequest, page, limit int) (yd4b.AddressResponse, error) {
+	return s.client.AddressZipContext(ctx,
+		yd4b.WithPrefCode(req.GetPrefCode()), yd4b.WithPrefName(req.GetPrefName()), yd4b.WithPrefKana(req.GetPrefKana()), yd4b.WithPrefRoma(req.GetPrefRoma()),
+		yd4b.WithCityCode(req.GetCityCode()), yd4b.WithCityName(req.GetCityName()), yd4b.WithCityKana(req.GetCityKana()), yd4b.WithCityRoma(req.GetCityRoma()),
+		yd4b.WithTownName(req.GetTownName()), yd4b.WithTownKana(req.GetTownKana()), yd4b.WithTownRoma(req.GetTownRoma()),
+		yd4b.WithFreeword(req.GetFreeword()), yd4b.WithFlgGetCity(int(req.GetFlgGetcity())), yd4b.WithFlgGetPref(int(req.GetFlgGetpref())),
+		yd4b.WithAZPage(page), yd4b.WithAZLimit(limit),
+	)
+}
+
+// fromSearchcodeResponse は [yd4b.SearchcodeResponse] をメッセージに変換します。
+func fromSearchcodeResponse(res yd4b.SearchcodeResponse) *SearchcodeResponse {
+	out := &SearchcodeResponse{
+		Page:       int32(res.Page),
+		Limit:      int32(res.Limit),
+		Count:      int32(res.Count),
+		Searchtype: res.Searchtype,
+		Addresses:  make([]*SearchcodeAddressItem, 0, len(res.Addresses)),
+	}
+	for _, item := range res.Addresses {
+		out.Addresses = append(out.Addresses, fromSearchcodeAddressItem(item))
+	}
+	return out
+}
+
+// fromSearchcodeAddressItem は [yd4b.SearchcodeAddressItem] をメッセージに変換します。
+func fromSearchcodeAddressItem(item yd4b.SearchcodeAddressItem) *SearchcodeAddressItem {
+	return &SearchcodeAddressItem{
+		Dgacode:   item.DgaCode,
+		ZipCode:   item.ZipCode,
+		PrefCode:  item.PrefCode,
+		PrefName:  item.PrefName,
+		PrefKana:  item.PrefKana,
+		PrefRoma:  item.PrefRoma,
+		CityCode:  item.CityCode,
+		CityName:  item.CityName,
+		CityKana:  item.CityKana,
+		CityRoma:  item.CityRoma,
+		TownName:  item.TownName,
+		TownKana:  item.TownKana,
+		TownRoma:  item.TownRoma,
+		BizName:   item.BizName,
+		BizKana:   item.BizKana,
+		BizRoma:   item.BizRoma,
+		BlockName: item.BlockName,
+		OtherName: item.OtherName,
+		Address:   item.Address,
+		Longitude: item.Longitude,
+		Latitude:  item.Latitude,
+	}
+}
+
+// fromAddressResponse は [yd4b.AddressResponse] をメッセージに変換します。
+func fromAddressResponse(res yd4b.AddressResponse) *AddressZipResponse {
+	out := &AddressZipResponse{
+		Level:     int32(res.Level),
+		Page:      int32(res.Page),
+		Limit:     int32(res.Limit),
+		Count:     int32(res.Count),
+		Addresses: make([]*AddressItem, 0, len(res.Addresses)),
+	}
+	for _, item := range res.Addresses {
+		out.Addresses = append(out.Addresses, fromAddressItem(item))
+	}
+	return out
+}
+
+// fromAddressItem は [yd4b.AddressItem] をメッセージに変換します。
+func fromAddressItem(item yd4b.AddressItem) *AddressItem {
+	return &AddressItem{
+		ZipCode:  item.ZipCode,
+		PrefCode: item.PrefCode,
+		PrefName: item.PrefName,
+		PrefKana: item.PrefKana,
+		PrefRoma: item.PrefRoma,
+		CityCode: item.CityCode,
+		CityName: item.CityName,
+		CityKana: item.CityKana,
+		CityRoma: item.CityRoma,
+		TownName: item.TownName,
+		TownKana: item.TownKana,
+		TownRoma: item.TownRoma,
+	}
+}
+
+// statusCodes は [yd4b.Error] の HTTP ステータスコードと gRPC のステータスコードの対応です。
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// toStatus はエラーを gRPC のステータスに変換します。
+func toStatus(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	var e *yd4b.Error
+	if !errors.As(err, &e) {
+		return status.Error(codes.Unknown, err.Error())
+	}
+	code, ok := statusCodes[e.StatusCode]
+	if !ok {
+		code = codes.Unknown
+	}
+	return status.Error(code, e.Message)
+}
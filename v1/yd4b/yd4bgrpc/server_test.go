@@ -0,0 +1,196 @@
+package yd4bgrpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b"
+	"github.com/aethiopicuschan/yd4b-go/v1/yd4b/yd4bgrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// totalItems はスタブが返す住所の総件数です。
+const totalItems = 5
+
+// fakeDo は totalItems 件の住所をページ分割して返す doFunc です。
+// コード "0000000" には 404 を返します。
+func fakeDo(req *http.Request) (*http.Response, error) {
+	page, limit := 1, 100
+	var body string
+	switch {
+	case strings.Contains(req.URL.Path, "/searchcode/0000000"):
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"message":"not found"}`)),
+		}, nil
+	case req.Method == http.MethodGet:
+		q := req.URL.Query()
+		if v := q.Get("page"); v != "" {
+			page, _ = strconv.Atoi(v)
+		}
+		if v := q.Get("limit"); v != "" {
+			limit, _ = strconv.Atoi(v)
+		}
+	default:
+		var r struct {
+			Page  int `json:"page"`
+			Limit int `json:"limit"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&r)
+		if r.Page > 0 {
+			page = r.Page
+		}
+		if r.Limit > 0 {
+			limit = r.Limit
+		}
+	}
+
+	var items []string
+	for i := (page - 1) * limit; i < min(page*limit, totalItems); i++ {
+		items = append(items, fmt.Sprintf(`{"zip_code":"100000%d","pref_name":"東京都"}`, i))
+	}
+	body = fmt.Sprintf(`{"level":3,"page":%d,"limit":%d,"count":%d,"addresses":[%s]}`, page, limit, totalItems, strings.Join(items, ","))
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+}
+
+// newTestClient は bufconn 上で Server を起動し、生成されたクライアントを返します。
+func newTestClient(t *testing.T) yd4bgrpc.AddressLookupClient {
+	t.Helper()
+
+	client := yd4b.NewClient("https://api.example.com", "id", "secret", "1.2.3.4")
+	client.SetToken("token")
+	client.SetDoFunc(fakeDo)
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	yd4bgrpc.NewServer(client).Register(gs)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return yd4bgrpc.NewAddressLookupClient(conn)
+}
+
+func TestServer_Searchcode(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *yd4bgrpc.SearchcodeRequest
+		wantCode  codes.Code
+		wantCount int
+	}{
+		{name: "ok", req: &yd4bgrpc.SearchcodeRequest{Code: "1000001", Limit: 2}, wantCode: codes.OK, wantCount: 2},
+		{name: "not found", req: &yd4bgrpc.SearchcodeRequest{Code: "0000000"}, wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newTestClient(t)
+			res, err := c.Searchcode(context.Background(), tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if err != nil {
+				return
+			}
+			assert.Equal(t, int32(totalItems), res.GetCount())
+			assert.Len(t, res.GetAddresses(), tt.wantCount)
+			assert.Equal(t, "1000000", res.GetAddresses()[0].GetZipCode())
+		})
+	}
+}
+
+func TestServer_SearchcodeStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *yd4bgrpc.SearchcodeRequest
+		wantZips []string
+		wantCode codes.Code
+	}{
+		{
+			name:     "all pages",
+			req:      &yd4bgrpc.SearchcodeRequest{Code: "1000001", Limit: 2},
+			wantZips: []string{"1000000", "1000001", "1000002", "1000003", "1000004"},
+		},
+		{
+			name:     "from page",
+			req:      &yd4bgrpc.SearchcodeRequest{Code: "1000001", Page: 2, Limit: 2},
+			wantZips: []string{"1000002", "1000003", "1000004"},
+		},
+		{name: "not found", req: &yd4bgrpc.SearchcodeRequest{Code: "0000000"}, wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newTestClient(t)
+			stream, err := c.SearchcodeStream(context.Background(), tt.req)
+			require.NoError(t, err)
+
+			var zips []string
+			for {
+				item, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					assert.Equal(t, tt.wantCode, status.Code(err))
+					return
+				}
+				zips = append(zips, item.GetZipCode())
+			}
+			assert.Equal(t, codes.OK, tt.wantCode)
+			assert.Equal(t, tt.wantZips, zips)
+		})
+	}
+}
+
+func TestServer_AddressZip(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	res, err := c.AddressZip(context.Background(), &yd4bgrpc.AddressZipRequest{PrefCode: "13", Limit: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), res.GetLevel())
+	assert.Equal(t, int32(totalItems), res.GetCount())
+	assert.Len(t, res.GetAddresses(), 3)
+	assert.Equal(t, "東京都", res.GetAddresses()[0].GetPrefName())
+}
+
+func TestServer_AddressZipStream(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	stream, err := c.AddressZipStream(context.Background(), &yd4bgrpc.AddressZipRequest{PrefCode: "13", Limit: 2})
+	require.NoError(t, err)
+
+	var zips []string
+	for {
+		item, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		zips = append(zips, item.GetZipCode())
+	}
+	assert.Equal(t, []string{"1000000", "1000001", "1000002", "1000003", "1000004"}, zips)
+}
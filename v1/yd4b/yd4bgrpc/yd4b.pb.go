@@ -0,0 +1,944 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: yd4b.proto
+
+// yd4b.v1 は日本郵便の郵便番号・デジタルアドレスAPIを gRPC で公開するためのサービス定義です。
+
+package yd4bgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SearchcodeRequest は Searchcode のリクエストです。
+type SearchcodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`              // 検索するコード
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`             // ページ番号（ストリームでは開始ページ）
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`           // 取得最大レコード数（ストリームでは1ページあたりの件数）
+	Choikitype    int32                  `protobuf:"varint,4,opt,name=choikitype,proto3" json:"choikitype,omitempty"` // 町域フィールドタイプ（1:括弧なし、2:括弧あり）
+	Searchtype    int32                  `protobuf:"varint,5,opt,name=searchtype,proto3" json:"searchtype,omitempty"` // 検索方法タイプ（1:全対象、2:事業所郵便除外）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchcodeRequest) Reset() {
+	*x = SearchcodeRequest{}
+	mi := &file_yd4b_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchcodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchcodeRequest) ProtoMessage() {}
+
+func (x *SearchcodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_yd4b_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchcodeRequest.ProtoReflect.Descriptor instead.
+func (*SearchcodeRequest) Descriptor() ([]byte, []int) {
+	return file_yd4b_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchcodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SearchcodeRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchcodeRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchcodeRequest) GetChoikitype() int32 {
+	if x != nil {
+		return x.Choikitype
+	}
+	return 0
+}
+
+func (x *SearchcodeRequest) GetSearchtype() int32 {
+	if x != nil {
+		return x.Searchtype
+	}
+	return 0
+}
+
+// SearchcodeResponse は Searchcode のレスポンスです。
+type SearchcodeResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Page          int32                    `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`            // ページ数
+	Limit         int32                    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`          // 取得最大レコード数
+	Count         int32                    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`          // 該当データ数
+	Searchtype    string                   `protobuf:"bytes,4,opt,name=searchtype,proto3" json:"searchtype,omitempty"` // 検索タイプ（"dgacode" / "zipcode" / "bizzipcode"）
+	Addresses     []*SearchcodeAddressItem `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`   // 検索結果の住所情報一覧
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchcodeResponse) Reset() {
+	*x = SearchcodeResponse{}
+	mi := &file_yd4b_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchcodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchcodeResponse) ProtoMessage() {}
+
+func (x *SearchcodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_yd4b_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchcodeResponse.ProtoReflect.Descriptor instead.
+func (*SearchcodeResponse) Descriptor() ([]byte, []int) {
+	return file_yd4b_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchcodeResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchcodeResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchcodeResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SearchcodeResponse) GetSearchtype() string {
+	if x != nil {
+		return x.Searchtype
+	}
+	return ""
+}
+
+func (x *SearchcodeResponse) GetAddresses() []*SearchcodeAddressItem {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+// SearchcodeAddressItem は Searchcode の住所情報です。
+type SearchcodeAddressItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dgacode       *string                `protobuf:"bytes,1,opt,name=dgacode,proto3,oneof" json:"dgacode,omitempty"`                       // デジタルアドレスコード
+	ZipCode       string                 `protobuf:"bytes,2,opt,name=zip_code,json=zipCode,proto3" json:"zip_code,omitempty"`              // 郵便番号
+	PrefCode      string                 `protobuf:"bytes,3,opt,name=pref_code,json=prefCode,proto3" json:"pref_code,omitempty"`           // 都道府県コード
+	PrefName      string                 `protobuf:"bytes,4,opt,name=pref_name,json=prefName,proto3" json:"pref_name,omitempty"`           // 都道府県名
+	PrefKana      *string                `protobuf:"bytes,5,opt,name=pref_kana,json=prefKana,proto3,oneof" json:"pref_kana,omitempty"`     // 都道府県名カナ
+	PrefRoma      *string                `protobuf:"bytes,6,opt,name=pref_roma,json=prefRoma,proto3,oneof" json:"pref_roma,omitempty"`     // 都道府県名ローマ字
+	CityCode      string                 `protobuf:"bytes,7,opt,name=city_code,json=cityCode,proto3" json:"city_code,omitempty"`           // 市区町村コード
+	CityName      string                 `protobuf:"bytes,8,opt,name=city_name,json=cityName,proto3" json:"city_name,omitempty"`           // 市区町村名
+	CityKana      *string                `protobuf:"bytes,9,opt,name=city_kana,json=cityKana,proto3,oneof" json:"city_kana,omitempty"`     // 市区町村名カナ
+	CityRoma      *string                `protobuf:"bytes,10,opt,name=city_roma,json=cityRoma,proto3,oneof" json:"city_roma,omitempty"`    // 市区町村名ローマ字
+	TownName      string                 `protobuf:"bytes,11,opt,name=town_name,json=townName,proto3" json:"town_name,omitempty"`          // 町域名
+	TownKana      *string                `protobuf:"bytes,12,opt,name=town_kana,json=townKana,proto3,oneof" json:"town_kana,omitempty"`    // 町域名カナ
+	TownRoma      *string                `protobuf:"bytes,13,opt,name=town_roma,json=townRoma,proto3,oneof" json:"town_roma,omitempty"`    // 町域名ローマ字
+	BizName       *string                `protobuf:"bytes,14,opt,name=biz_name,json=bizName,proto3,oneof" json:"biz_name,omitempty"`       // 事業所名
+	BizKana       *string                `protobuf:"bytes,15,opt,name=biz_kana,json=bizKana,proto3,oneof" json:"biz_kana,omitempty"`       // 事業所名カナ
+	BizRoma       *string                `protobuf:"bytes,16,opt,name=biz_roma,json=bizRoma,proto3,oneof" json:"biz_roma,omitempty"`       // 事業所名ローマ字
+	BlockName     *string                `protobuf:"bytes,17,opt,name=block_name,json=blockName,proto3,oneof" json:"block_name,omitempty"` // 町域字等
+	OtherName     *string                `protobuf:"bytes,18,opt,name=other_name,json=otherName,proto3,oneof" json:"other_name,omitempty"` // その他名称
+	Address       *string                `protobuf:"bytes,19,opt,name=address,proto3,oneof" json:"address,omitempty"`                      // 住所
+	Longitude     *float64               `protobuf:"fixed64,20,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`                // 経度
+	Latitude      *float64               `protobuf:"fixed64,21,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`                  // 緯度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchcodeAddressItem) Reset() {
+	*x = SearchcodeAddressItem{}
+	mi := &file_yd4b_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchcodeAddressItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchcodeAddressItem) ProtoMessage() {}
+
+func (x *SearchcodeAddressItem) ProtoReflect() protoreflect.Message {
+	mi := &file_yd4b_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchcodeAddressItem.ProtoReflect.Descriptor instead.
+func (*SearchcodeAddressItem) Descriptor() ([]byte, []int) {
+	return file_yd4b_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchcodeAddressItem) GetDgacode() string {
+	if x != nil && x.Dgacode != nil {
+		return *x.Dgacode
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetZipCode() string {
+	if x != nil {
+		return x.ZipCode
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetPrefCode() string {
+	if x != nil {
+		return x.PrefCode
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetPrefName() string {
+	if x != nil {
+		return x.PrefName
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetPrefKana() string {
+	if x != nil && x.PrefKana != nil {
+		return *x.PrefKana
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetPrefRoma() string {
+	if x != nil && x.PrefRoma != nil {
+		return *x.PrefRoma
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetCityCode() string {
+	if x != nil {
+		return x.CityCode
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetCityKana() string {
+	if x != nil && x.CityKana != nil {
+		return *x.CityKana
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetCityRoma() string {
+	if x != nil && x.CityRoma != nil {
+		return *x.CityRoma
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetTownName() string {
+	if x != nil {
+		return x.TownName
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetTownKana() string {
+	if x != nil && x.TownKana != nil {
+		return *x.TownKana
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetTownRoma() string {
+	if x != nil && x.TownRoma != nil {
+		return *x.TownRoma
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetBizName() string {
+	if x != nil && x.BizName != nil {
+		return *x.BizName
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetBizKana() string {
+	if x != nil && x.BizKana != nil {
+		return *x.BizKana
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetBizRoma() string {
+	if x != nil && x.BizRoma != nil {
+		return *x.BizRoma
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetBlockName() string {
+	if x != nil && x.BlockName != nil {
+		return *x.BlockName
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetOtherName() string {
+	if x != nil && x.OtherName != nil {
+		return *x.OtherName
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *SearchcodeAddressItem) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *SearchcodeAddressItem) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+// AddressZipRequest は AddressZip のリクエストです。
+type AddressZipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrefCode      string                 `protobuf:"bytes,1,opt,name=pref_code,json=prefCode,proto3" json:"pref_code,omitempty"`         // 都道府県コード
+	PrefName      string                 `protobuf:"bytes,2,opt,name=pref_name,json=prefName,proto3" json:"pref_name,omitempty"`         // 都道府県名
+	PrefKana      string                 `protobuf:"bytes,3,opt,name=pref_kana,json=prefKana,proto3" json:"pref_kana,omitempty"`         // 都道府県名（カナ）
+	PrefRoma      string                 `protobuf:"bytes,4,opt,name=pref_roma,json=prefRoma,proto3" json:"pref_roma,omitempty"`         // 都道府県名（ローマ字）
+	CityCode      string                 `protobuf:"bytes,5,opt,name=city_code,json=cityCode,proto3" json:"city_code,omitempty"`         // 市区町村コード
+	CityName      string                 `protobuf:"bytes,6,opt,name=city_name,json=cityName,proto3" json:"city_name,omitempty"`         // 市区町村名
+	CityKana      string                 `protobuf:"bytes,7,opt,name=city_kana,json=cityKana,proto3" json:"city_kana,omitempty"`         // 市区町村名（カナ）
+	CityRoma      string                 `protobuf:"bytes,8,opt,name=city_roma,json=cityRoma,proto3" json:"city_roma,omitempty"`         // 市区町村名（ローマ字）
+	TownName      string                 `protobuf:"bytes,9,opt,name=town_name,json=townName,proto3" json:"town_name,omitempty"`         // 町域名
+	TownKana      string                 `protobuf:"bytes,10,opt,name=town_kana,json=townKana,proto3" json:"town_kana,omitempty"`        // 町域名（カナ）
+	TownRoma      string                 `protobuf:"bytes,11,opt,name=town_roma,json=townRoma,proto3" json:"town_roma,omitempty"`        // 町域名（ローマ字）
+	Freeword      string                 `protobuf:"bytes,12,opt,name=freeword,proto3" json:"freeword,omitempty"`                        // フリーワード検索
+	FlgGetcity    int32                  `protobuf:"varint,13,opt,name=flg_getcity,json=flgGetcity,proto3" json:"flg_getcity,omitempty"` // 市区町村一覧取得フラグ（1: 有効）
+	FlgGetpref    int32                  `protobuf:"varint,14,opt,name=flg_getpref,json=flgGetpref,proto3" json:"flg_getpref,omitempty"` // 都道府県一覧取得フラグ（1: 有効）
+	Page          int32                  `protobuf:"varint,15,opt,name=page,proto3" json:"page,omitempty"`                               // ページ番号（ストリームでは開始ページ）
+	Limit         int32                  `protobuf:"varint,16,opt,name=limit,proto3" json:"limit,omitempty"`                             // 取得件数の上限（ストリームでは1ページあたりの件数）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressZipRequest) Reset() {
+	*x = AddressZipRequest{}
+	mi := &file_yd4b_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressZipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressZipRequest) ProtoMessage() {}
+
+func (x *AddressZipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_yd4b_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressZipRequest.ProtoReflect.Descriptor instead.
+func (*AddressZipRequest) Descriptor() ([]byte, []int) {
+	return file_yd4b_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddressZipRequest) GetPrefCode() string {
+	if x != nil {
+		return x.PrefCode
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetPrefName() string {
+	if x != nil {
+		return x.PrefName
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetPrefKana() string {
+	if x != nil {
+		return x.PrefKana
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetPrefRoma() string {
+	if x != nil {
+		return x.PrefRoma
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetCityCode() string {
+	if x != nil {
+		return x.CityCode
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetCityKana() string {
+	if x != nil {
+		return x.CityKana
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetCityRoma() string {
+	if x != nil {
+		return x.CityRoma
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetTownName() string {
+	if x != nil {
+		return x.TownName
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetTownKana() string {
+	if x != nil {
+		return x.TownKana
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetTownRoma() string {
+	if x != nil {
+		return x.TownRoma
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetFreeword() string {
+	if x != nil {
+		return x.Freeword
+	}
+	return ""
+}
+
+func (x *AddressZipRequest) GetFlgGetcity() int32 {
+	if x != nil {
+		return x.FlgGetcity
+	}
+	return 0
+}
+
+func (x *AddressZipRequest) GetFlgGetpref() int32 {
+	if x != nil {
+		return x.FlgGetpref
+	}
+	return 0
+}
+
+func (x *AddressZipRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AddressZipRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// AddressZipResponse は AddressZip のレスポンスです。
+type AddressZipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`        // 検索レベル
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`          // 現在のページ番号
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`        // １ページあたりの件数
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`        // 総件数
+	Addresses     []*AddressItem         `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"` // 検索結果の住所データ一覧
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressZipResponse) Reset() {
+	*x = AddressZipResponse{}
+	mi := &file_yd4b_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressZipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressZipResponse) ProtoMessage() {}
+
+func (x *AddressZipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_yd4b_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressZipResponse.ProtoReflect.Descriptor instead.
+func (*AddressZipResponse) Descriptor() ([]byte, []int) {
+	return file_yd4b_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddressZipResponse) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *AddressZipResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AddressZipResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AddressZipResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AddressZipResponse) GetAddresses() []*AddressItem {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+// AddressItem は AddressZip の住所情報です。
+type AddressItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZipCode       string                 `protobuf:"bytes,1,opt,name=zip_code,json=zipCode,proto3" json:"zip_code,omitempty"`     // 郵便番号
+	PrefCode      string                 `protobuf:"bytes,2,opt,name=pref_code,json=prefCode,proto3" json:"pref_code,omitempty"`  // 都道府県コード
+	PrefName      string                 `protobuf:"bytes,3,opt,name=pref_name,json=prefName,proto3" json:"pref_name,omitempty"`  // 都道府県名
+	PrefKana      string                 `protobuf:"bytes,4,opt,name=pref_kana,json=prefKana,proto3" json:"pref_kana,omitempty"`  // 都道府県名（カナ）
+	PrefRoma      string                 `protobuf:"bytes,5,opt,name=pref_roma,json=prefRoma,proto3" json:"pref_roma,omitempty"`  // 都道府県名（ローマ字）
+	CityCode      string                 `protobuf:"bytes,6,opt,name=city_code,json=cityCode,proto3" json:"city_code,omitempty"`  // 市区町村コード
+	CityName      string                 `protobuf:"bytes,7,opt,name=city_name,json=cityName,proto3" json:"city_name,omitempty"`  // 市区町村名
+	CityKana      string                 `protobuf:"bytes,8,opt,name=city_kana,json=cityKana,proto3" json:"city_kana,omitempty"`  // 市区町村名（カナ）
+	CityRoma      string                 `protobuf:"bytes,9,opt,name=city_roma,json=cityRoma,proto3" json:"city_roma,omitempty"`  // 市区町村名（ローマ字）
+	TownName      string                 `protobuf:"bytes,10,opt,name=town_name,json=townName,proto3" json:"town_name,omitempty"` // 町域名
+	TownKana      string                 `protobuf:"bytes,11,opt,name=town_kana,json=townKana,proto3" json:"town_kana,omitempty"` // 町域名（カナ）
+	TownRoma      string                 `protobuf:"bytes,12,opt,name=town_roma,json=townRoma,proto3" json:"town_roma,omitempty"` // 町域名（ローマ字）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressItem) Reset() {
+	*x = AddressItem{}
+	mi := &file_yd4b_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressItem) ProtoMessage() {}
+
+func (x *AddressItem) ProtoReflect() protoreflect.Message {
+	mi := &file_yd4b_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressItem.ProtoReflect.Descriptor instead.
+func (*AddressItem) Descriptor() ([]byte, []int) {
+	return file_yd4b_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddressItem) GetZipCode() string {
+	if x != nil {
+		return x.ZipCode
+	}
+	return ""
+}
+
+func (x *AddressItem) GetPrefCode() string {
+	if x != nil {
+		return x.PrefCode
+	}
+	return ""
+}
+
+func (x *AddressItem) GetPrefName() string {
+	if x != nil {
+		return x.PrefName
+	}
+	return ""
+}
+
+func (x *AddressItem) GetPrefKana() string {
+	if x != nil {
+		return x.PrefKana
+	}
+	return ""
+}
+
+func (x *AddressItem) GetPrefRoma() string {
+	if x != nil {
+		return x.PrefRoma
+	}
+	return ""
+}
+
+func (x *AddressItem) GetCityCode() string {
+	if x != nil {
+		return x.CityCode
+	}
+	return ""
+}
+
+func (x *AddressItem) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *AddressItem) GetCityKana() string {
+	if x != nil {
+		return x.CityKana
+	}
+	return ""
+}
+
+func (x *AddressItem) GetCityRoma() string {
+	if x != nil {
+		return x.CityRoma
+	}
+	return ""
+}
+
+func (x *AddressItem) GetTownName() string {
+	if x != nil {
+		return x.TownName
+	}
+	return ""
+}
+
+func (x *AddressItem) GetTownKana() string {
+	if x != nil {
+		return x.TownKana
+	}
+	return ""
+}
+
+func (x *AddressItem) GetTownRoma() string {
+	if x != nil {
+		return x.TownRoma
+	}
+	return ""
+}
+
+var File_yd4b_proto protoreflect.FileDescriptor
+
+const file_yd4b_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"yd4b.proto\x12\ayd4b.v1\"\x91\x01\n" +
+	"\x11SearchcodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1e\n" +
+	"\n" +
+	"choikitype\x18\x04 \x01(\x05R\n" +
+	"choikitype\x12\x1e\n" +
+	"\n" +
+	"searchtype\x18\x05 \x01(\x05R\n" +
+	"searchtype\"\xb2\x01\n" +
+	"\x12SearchcodeResponse\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x1e\n" +
+	"\n" +
+	"searchtype\x18\x04 \x01(\tR\n" +
+	"searchtype\x12<\n" +
+	"\taddresses\x18\x05 \x03(\v2\x1e.yd4b.v1.SearchcodeAddressItemR\taddresses\"\x85\a\n" +
+	"\x15SearchcodeAddressItem\x12\x1d\n" +
+	"\adgacode\x18\x01 \x01(\tH\x00R\adgacode\x88\x01\x01\x12\x19\n" +
+	"\bzip_code\x18\x02 \x01(\tR\azipCode\x12\x1b\n" +
+	"\tpref_code\x18\x03 \x01(\tR\bprefCode\x12\x1b\n" +
+	"\tpref_name\x18\x04 \x01(\tR\bprefName\x12 \n" +
+	"\tpref_kana\x18\x05 \x01(\tH\x01R\bprefKana\x88\x01\x01\x12 \n" +
+	"\tpref_roma\x18\x06 \x01(\tH\x02R\bprefRoma\x88\x01\x01\x12\x1b\n" +
+	"\tcity_code\x18\a \x01(\tR\bcityCode\x12\x1b\n" +
+	"\tcity_name\x18\b \x01(\tR\bcityName\x12 \n" +
+	"\tcity_kana\x18\t \x01(\tH\x03R\bcityKana\x88\x01\x01\x12 \n" +
+	"\tcity_roma\x18\n" +
+	" \x01(\tH\x04R\bcityRoma\x88\x01\x01\x12\x1b\n" +
+	"\ttown_name\x18\v \x01(\tR\btownName\x12 \n" +
+	"\ttown_kana\x18\f \x01(\tH\x05R\btownKana\x88\x01\x01\x12 \n" +
+	"\ttown_roma\x18\r \x01(\tH\x06R\btownRoma\x88\x01\x01\x12\x1e\n" +
+	"\bbiz_name\x18\x0e \x01(\tH\aR\abizName\x88\x01\x01\x12\x1e\n" +
+	"\bbiz_kana\x18\x0f \x01(\tH\bR\abizKana\x88\x01\x01\x12\x1e\n" +
+	"\bbiz_roma\x18\x10 \x01(\tH\tR\abizRoma\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"block_name\x18\x11 \x01(\tH\n" +
+	"R\tblockName\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"other_name\x18\x12 \x01(\tH\vR\totherName\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\x13 \x01(\tH\fR\aaddress\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x14 \x01(\x01H\rR\tlongitude\x88\x01\x01\x12\x1f\n" +
+	"\blatitude\x18\x15 \x01(\x01H\x0eR\blatitude\x88\x01\x01B\n" +
+	"\n" +
+	"\b_dgacodeB\f\n" +
+	"\n" +
+	"_pref_kanaB\f\n" +
+	"\n" +
+	"_pref_romaB\f\n" +
+	"\n" +
+	"_city_kanaB\f\n" +
+	"\n" +
+	"_city_romaB\f\n" +
+	"\n" +
+	"_town_kanaB\f\n" +
+	"\n" +
+	"_town_romaB\v\n" +
+	"\t_biz_nameB\v\n" +
+	"\t_biz_kanaB\v\n" +
+	"\t_biz_romaB\r\n" +
+	"\v_block_nameB\r\n" +
+	"\v_other_nameB\n" +
+	"\n" +
+	"\b_addressB\f\n" +
+	"\n" +
+	"_longitudeB\v\n" +
+	"\t_latitude\"\xda\x03\n" +
+	"\x11AddressZipRequest\x12\x1b\n" +
+	"\tpref_code\x18\x01 \x01(\tR\bprefCode\x12\x1b\n" +
+	"\tpref_name\x18\x02 \x01(\tR\bprefName\x12\x1b\n" +
+	"\tpref_kana\x18\x03 \x01(\tR\bprefKana\x12\x1b\n" +
+	"\tpref_roma\x18\x04 \x01(\tR\bprefRoma\x12\x1b\n" +
+	"\tcity_code\x18\x05 \x01(\tR\bcityCode\x12\x1b\n" +
+	"\tcity_name\x18\x06 \x01(\tR\bcityName\x12\x1b\n" +
+	"\tcity_kana\x18\a \x01(\tR\bcityKana\x12\x1b\n" +
+	"\tcity_roma\x18\b \x01(\tR\bcityRoma\x12\x1b\n" +
+	"\ttown_name\x18\t \x01(\tR\btownName\x12\x1b\n" +
+	"\ttown_kana\x18\n" +
+	" \x01(\tR\btownKana\x12\x1b\n" +
+	"\ttown_roma\x18\v \x01(\tR\btownRoma\x12\x1a\n" +
+	"\bfreeword\x18\f \x01(\tR\bfreeword\x12\x1f\n" +
+	"\vflg_getcity\x18\r \x01(\x05R\n" +
+	"flgGetcity\x12\x1f\n" +
+	"\vflg_getpref\x18\x0e \x01(\x05R\n" +
+	"flgGetpref\x12\x12\n" +
+	"\x04page\x18\x0f \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x10 \x01(\x05R\x05limit\"\x9e\x01\n" +
+	"\x12AddressZipResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x05R\x05level\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x122\n" +
+	"\taddresses\x18\x05 \x03(\v2\x14.yd4b.v1.AddressItemR\taddresses\"\xe7\x02\n" +
+	"\vAddressItem\x12\x19\n" +
+	"\bzip_code\x18\x01 \x01(\tR\azipCode\x12\x1b\n" +
+	"\tpref_code\x18\x02 \x01(\tR\bprefCode\x12\x1b\n" +
+	"\tpref_name\x18\x03 \x01(\tR\bprefName\x12\x1b\n" +
+	"\tpref_kana\x18\x04 \x01(\tR\bprefKana\x12\x1b\n" +
+	"\tpref_roma\x18\x05 \x01(\tR\bprefRoma\x12\x1b\n" +
+	"\tcity_code\x18\x06 \x01(\tR\bcityCode\x12\x1b\n" +
+	"\tcity_name\x18\a \x01(\tR\bcityName\x12\x1b\n" +
+	"\tcity_kana\x18\b \x01(\tR\bcityKana\x12\x1b\n" +
+	"\tcity_roma\x18\t \x01(\tR\bcityRoma\x12\x1b\n" +
+	"\ttown_name\x18\n" +
+	" \x01(\tR\btownName\x12\x1b\n" +
+	"\ttown_kana\x18\v \x01(\tR\btownKana\x12\x1b\n" +
+	"\ttown_roma\x18\f \x01(\tR\btownRoma2\xb7\x02\n" +
+	"\rAddressLookup\x12E\n" +
+	"\n" +
+	"Searchcode\x12\x1a.yd4b.v1.SearchcodeRequest\x1a\x1b.yd4b.v1.SearchcodeResponse\x12P\n" +
+	"\x10SearchcodeStream\x12\x1a.yd4b.v1.SearchcodeRequest\x1a\x1e.yd4b.v1.SearchcodeAddressItem0\x01\x12E\n" +
+	"\n" +
+	"AddressZip\x12\x1a.yd4b.v1.AddressZipRequest\x1a\x1b.yd4b.v1.AddressZipResponse\x12F\n" +
+	"\x10AddressZipStream\x12\x1a.yd4b.v1.AddressZipRequest\x1a\x14.yd4b.v1.AddressItem0\x01B5Z3github.com/aethiopicuschan/yd4b-go/v1/yd4b/yd4bgrpcb\x06proto3"
+
+var (
+	file_yd4b_proto_rawDescOnce sync.Once
+	file_yd4b_proto_rawDescData []byte
+)
+
+func file_yd4b_proto_rawDescGZIP() []byte {
+	file_yd4b_proto_rawDescOnce.Do(func() {
+		file_yd4b_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_yd4b_proto_rawDesc), len(file_yd4b_proto_rawDesc)))
+	})
+	return file_yd4b_proto_rawDescData
+}
+
+var file_yd4b_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_yd4b_proto_goTypes = []any{
+	(*SearchcodeRequest)(nil),     // 0: yd4b.v1.SearchcodeRequest
+	(*SearchcodeResponse)(nil),    // 1: yd4b.v1.SearchcodeResponse
+	(*SearchcodeAddressItem)(nil), // 2: yd4b.v1.SearchcodeAddressItem
+	(*AddressZipRequest)(nil),     // 3: yd4b.v1.AddressZipRequest
+	(*AddressZipResponse)(nil),    // 4: yd4b.v1.AddressZipResponse
+	(*AddressItem)(nil),           // 5: yd4b.v1.AddressItem
+}
+var file_yd4b_proto_depIdxs = []int32{
+	2, // 0: yd4b.v1.SearchcodeResponse.addresses:type_name -> yd4b.v1.SearchcodeAddressItem
+	5, // 1: yd4b.v1.AddressZipResponse.addresses:type_name -> yd4b.v1.AddressItem
+	0, // 2: yd4b.v1.AddressLookup.Searchcode:input_type -> yd4b.v1.SearchcodeRequest
+	0, // 3: yd4b.v1.AddressLookup.SearchcodeStream:input_type -> yd4b.v1.SearchcodeRequest
+	3, // 4: yd4b.v1.AddressLookup.AddressZip:input_type -> yd4b.v1.AddressZipRequest
+	3, // 5: yd4b.v1.AddressLookup.AddressZipStream:input_type -> yd4b.v1.AddressZipRequest
+	1, // 6: yd4b.v1.AddressLookup.Searchcode:output_type -> yd4b.v1.SearchcodeResponse
+	2, // 7: yd4b.v1.AddressLookup.SearchcodeStream:output_type -> yd4b.v1.SearchcodeAddressItem
+	4, // 8: yd4b.v1.AddressLookup.AddressZip:output_type -> yd4b.v1.AddressZipResponse
+	5, // 9: yd4b.v1.AddressLookup.AddressZipStream:output_type -> yd4b.v1.AddressItem
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_yd4b_proto_init() }
+func file_yd4b_proto_init() {
+	if File_yd4b_proto != nil {
+		return
+	}
+	file_yd4b_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_yd4b_proto_rawDesc), len(file_yd4b_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_yd4b_proto_goTypes,
+		DependencyIndexes: file_yd4b_proto_depIdxs,
+		MessageInfos:      file_yd4b_proto_msgTypes,
+	}.Build()
+	File_yd4b_proto = out.File
+	file_yd4b_proto_goTypes = nil
+	file_yd4b_proto_depIdxs = nil
+}
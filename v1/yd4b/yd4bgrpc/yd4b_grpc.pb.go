@@ -0,0 +1,256 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: yd4b.proto
+
+// yd4b.v1 は日本郵便の郵便番号・デジタルアドレスAPIを gRPC で公開するためのサービス定義です。
+
+package yd4bgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AddressLookup_Searchcode_FullMethodName       = "/yd4b.v1.AddressLookup/Searchcode"
+	AddressLookup_SearchcodeStream_FullMethodName = "/yd4b.v1.AddressLookup/SearchcodeStream"
+	AddressLookup_AddressZip_FullMethodName       = "/yd4b.v1.AddressLookup/AddressZip"
+	AddressLookup_AddressZipStream_FullMethodName = "/yd4b.v1.AddressLookup/AddressZipStream"
+)
+
+// AddressLookupClient is the client API for AddressLookup service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AddressLookup は郵便番号・デジタルアドレスの検索を提供するサービスです。
+type AddressLookupClient interface {
+	// Searchcode は郵便番号・事業所個別郵便番号・デジタルアドレスから住所を検索します。
+	Searchcode(ctx context.Context, in *SearchcodeRequest, opts ...grpc.CallOption) (*SearchcodeResponse, error)
+	// SearchcodeStream は Searchcode の全ページを順に取得し、住所を1件ずつ返します。
+	SearchcodeStream(ctx context.Context, in *SearchcodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchcodeAddressItem], error)
+	// AddressZip は住所情報から郵便番号を検索します。
+	AddressZip(ctx context.Context, in *AddressZipRequest, opts ...grpc.CallOption) (*AddressZipResponse, error)
+	// AddressZipStream は AddressZip の全ページを順に取得し、住所を1件ずつ返します。
+	AddressZipStream(ctx context.Context, in *AddressZipRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddressItem], error)
+}
+
+type addressLookupClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAddressLookupClient(cc grpc.ClientConnInterface) AddressLookupClient {
+	return &addressLookupClient{cc}
+}
+
+func (c *addressLookupClient) Searchcode(ctx context.Context, in *SearchcodeRequest, opts ...grpc.CallOption) (*SearchcodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchcodeResponse)
+	err := c.cc.Invoke(ctx, AddressLookup_Searchcode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressLookupClient) SearchcodeStream(ctx context.Context, in *SearchcodeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchcodeAddressItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AddressLookup_ServiceDesc.Streams[0], AddressLookup_SearchcodeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchcodeRequest, SearchcodeAddressItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressLookup_SearchcodeStreamClient = grpc.ServerStreamingClient[SearchcodeAddressItem]
+
+func (c *addressLookupClient) AddressZip(ctx context.Context, in *AddressZipRequest, opts ...grpc.CallOption) (*AddressZipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressZipResponse)
+	err := c.cc.Invoke(ctx, AddressLookup_AddressZip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressLookupClient) AddressZipStream(ctx context.Context, in *AddressZipRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddressItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AddressLookup_ServiceDesc.Streams[1], AddressLookup_AddressZipStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AddressZipRequest, AddressItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressLookup_AddressZipStreamClient = grpc.ServerStreamingClient[AddressItem]
+
+// AddressLookupServer is the server API for AddressLookup service.
+// All implementations must embed UnimplementedAddressLookupServer
+// for forward compatibility.
+//
+// AddressLookup は郵便番号・デジタルアドレスの検索を提供するサービスです。
+type AddressLookupServer interface {
+	// Searchcode は郵便番号・事業所個別郵便番号・デジタルアドレスから住所を検索します。
+	Searchcode(context.Context, *SearchcodeRequest) (*SearchcodeResponse, error)
+	// SearchcodeStream は Searchcode の全ページを順に取得し、住所を1件ずつ返します。
+	SearchcodeStream(*SearchcodeRequest, grpc.ServerStreamingServer[SearchcodeAddressItem]) error
+	// AddressZip は住所情報から郵便番号を検索します。
+	AddressZip(context.Context, *AddressZipRequest) (*AddressZipResponse, error)
+	// AddressZipStream は AddressZip の全ページを順に取得し、住所を1件ずつ返します。
+	AddressZipStream(*AddressZipRequest, grpc.ServerStreamingServer[AddressItem]) error
+	mustEmbedUnimplementedAddressLookupServer()
+}
+
+// UnimplementedAddressLookupServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAddressLookupServer struct{}
+
+func (UnimplementedAddressLookupServer) Searchcode(context.Context, *SearchcodeRequest) (*SearchcodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Searchcode not implemented")
+}
+func (UnimplementedAddressLookupServer) SearchcodeStream(*SearchcodeRequest, grpc.ServerStreamingServer[SearchcodeAddressItem]) error {
+	return status.Error(codes.Unimplemented, "method SearchcodeStream not implemented")
+}
+func (UnimplementedAddressLookupServer) AddressZip(context.Context, *AddressZipRequest) (*AddressZipResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddressZip not implemented")
+}
+func (UnimplementedAddressLookupServer) AddressZipStream(*AddressZipRequest, grpc.ServerStreamingServer[AddressItem]) error {
+	return status.Error(codes.Unimplemented, "method AddressZipStream not implemented")
+}
+func (UnimplementedAddressLookupServer) mustEmbedUnimplementedAddressLookupServer() {}
+func (UnimplementedAddressLookupServer) testEmbeddedByValue()                       {}
+
+// UnsafeAddressLookupServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AddressLookupServer will
+// result in compilation errors.
+type UnsafeAddressLookupServer interface {
+	mustEmbedUnimplementedAddressLookupServer()
+}
+
+func RegisterAddressLookupServer(s grpc.ServiceRegistrar, srv AddressLookupServer) {
+	// If the following call panics, it indicates UnimplementedAddressLookupServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AddressLookup_ServiceDesc, srv)
+}
+
+func _AddressLookup_Searchcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchcodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressLookupServer).Searchcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressLookup_Searchcode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressLookupServer).Searchcode(ctx, req.(*SearchcodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressLookup_SearchcodeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchcodeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AddressLookupServer).SearchcodeStream(m, &grpc.GenericServerStream[SearchcodeRequest, SearchcodeAddressItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressLookup_SearchcodeStreamServer = grpc.ServerStreamingServer[SearchcodeAddressItem]
+
+func _AddressLookup_AddressZip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressZipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressLookupServer).AddressZip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressLookup_AddressZip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressLookupServer).AddressZip(ctx, req.(*AddressZipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressLookup_AddressZipStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AddressZipRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AddressLookupServer).AddressZipStream(m, &grpc.GenericServerStream[AddressZipRequest, AddressItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressLookup_AddressZipStreamServer = grpc.ServerStreamingServer[AddressItem]
+
+// AddressLookup_ServiceDesc is the grpc.ServiceDesc for AddressLookup service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AddressLookup_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "yd4b.v1.AddressLookup",
+	HandlerType: (*AddressLookupServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Searchcode",
+			Handler:    _AddressLookup_Searchcode_Handler,
+		},
+		{
+			MethodName: "AddressZip",
+			Handler:    _AddressLookup_AddressZip_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchcodeStream",
+			Handler:       _AddressLookup_SearchcodeStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AddressZipStream",
+			Handler:       _AddressLookup_AddressZipStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "yd4b.proto",
+}